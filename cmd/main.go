@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"avito-project/db"
+	"avito-project/handlers"
+	"avito-project/models"
 	"avito-project/routes"
+	"avito-project/scheduler"
 
 	"github.com/gorilla/mux"
 )
@@ -22,16 +27,31 @@ func main() {
 	// 	log.Fatalf("Error loading .env file: %v", err)
 	// }
 
-	// Подключение к PostgreSQL
+	// Подключение к PostgreSQL (пул соединений)
 	db.Connect()
 	defer db.Close()
 
 	// Запуск миграций
 	db.RunMigrations()
 
+	// Инициализация репозиториев
+	pool := db.GetPool()
+	handlers.Employees = models.NewEmployeeRepository(pool)
+	handlers.Organizations = models.NewOrganizationRepository(pool)
+	handlers.Tenders = models.NewTenderRepository(pool)
+	handlers.Bids = models.NewBidRepository(pool)
+	handlers.Sessions = models.NewSessionRepository(pool)
+	handlers.SessionTTL = durationEnv("SESSION_TTL", handlers.SessionTTL)
+
+	// Фоновая публикация и закрытие тендеров по расписанию
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scheduler.Run(ctx, handlers.Tenders, durationEnv("SCHEDULER_INTERVAL", scheduler.DefaultInterval))
+
 	// Настройка маршрутизации
 	serverAddress := os.Getenv("SERVER_ADDRESS")
 	router := mux.NewRouter()
+	router.Use(handlers.TimeoutMiddleware(durationEnv("REQUEST_TIMEOUT", defaultRequestTimeout)))
 	routes.SetupRoutes(router)
 
 	log.Printf("Server is running at %s", serverAddress)
@@ -40,3 +60,20 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// defaultRequestTimeout - срок обработки запроса, если REQUEST_TIMEOUT не задан
+const defaultRequestTimeout = 10 * time.Second
+
+// durationEnv возвращает длительность из переменной окружения или значение по умолчанию
+func durationEnv(name string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Fatalf("Invalid %s value %q", name, value)
+	}
+	log.Printf("%s set to %v", name, duration)
+	return duration
+}
@@ -3,39 +3,87 @@ package db
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
 	_ "github.com/lib/pq"
 )
 
-var conn *pgx.Conn
+var pool *pgxpool.Pool
 
-// Connect устанавливает соединение с базой данных PostgreSQL
+// Connect создает пул соединений с базой данных PostgreSQL.
+// Параметры пула задаются переменными окружения POSTGRES_POOL_*.
 func Connect() {
-	var err error
 	postgresConn := os.Getenv("POSTGRES_CONN")
 	log.Printf("Connecting to database at %s", postgresConn)
-	conn, err = pgx.Connect(context.Background(), postgresConn)
+
+	config, err := pgxpool.ParseConfig(postgresConn)
+	if err != nil {
+		log.Fatalf("Unable to parse database config: %v", err)
+	}
+	if err := applyPoolConfig(config); err != nil {
+		log.Fatalf("Invalid database pool config: %v", err)
+	}
+
+	pool, err = pgxpool.ConnectConfig(context.Background(), config)
 	if err != nil {
 		log.Fatalf("Unable to connect to database: %v", err)
 	}
-	log.Println("Successfully connected to the database")
+	log.Printf("Successfully connected to the database (min conns %d, max conns %d)", config.MinConns, config.MaxConns)
+}
+
+// applyPoolConfig переопределяет параметры пула значениями из переменных окружения
+func applyPoolConfig(config *pgxpool.Config) error {
+	if value := os.Getenv("POSTGRES_POOL_MIN_CONNS"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 0 {
+			return fmt.Errorf("POSTGRES_POOL_MIN_CONNS: invalid value %q", value)
+		}
+		config.MinConns = int32(n)
+	}
+	if value := os.Getenv("POSTGRES_POOL_MAX_CONNS"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 1 {
+			return fmt.Errorf("POSTGRES_POOL_MAX_CONNS: invalid value %q", value)
+		}
+		config.MaxConns = int32(n)
+	}
+	if config.MinConns > config.MaxConns {
+		return fmt.Errorf("min conns %d exceeds max conns %d", config.MinConns, config.MaxConns)
+	}
+	if value := os.Getenv("POSTGRES_POOL_HEALTH_CHECK_PERIOD"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("POSTGRES_POOL_HEALTH_CHECK_PERIOD: invalid value %q", value)
+		}
+		config.HealthCheckPeriod = d
+	}
+	if value := os.Getenv("POSTGRES_POOL_MAX_CONN_IDLE_TIME"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("POSTGRES_POOL_MAX_CONN_IDLE_TIME: invalid value %q", value)
+		}
+		config.MaxConnIdleTime = d
+	}
+	return nil
 }
 
-// GetConnection возвращает текущее соединение с базой данных
-func GetConnection() *pgx.Conn {
-	return conn
+// GetPool возвращает пул соединений с базой данных
+func GetPool() *pgxpool.Pool {
+	return pool
 }
 
-// Close закрывает соединение с базой данных
+// Close закрывает все соединения пула
 func Close() {
-	conn.Close(context.Background())
+	pool.Close()
 }
 
 // RunMigrations запускает миграции базы данных
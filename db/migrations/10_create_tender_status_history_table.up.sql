@@ -0,0 +1,11 @@
+CREATE TABLE tender_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,  -- связь с тендером
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT,  -- обязательна для закрытия и повторного открытия
+    changed_by UUID REFERENCES employee(id) ON DELETE SET NULL,  -- NULL - переход выполнен системой
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX tender_status_history_tender_id_idx ON tender_status_history (tender_id, changed_at);
@@ -0,0 +1,5 @@
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMPTZ;  -- после срока предложения не принимаются, тендер закрывается
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;  -- время автоматической публикации тендера в статусе CREATED
+
+CREATE INDEX IF NOT EXISTS tender_publish_at_idx ON tender (publish_at) WHERE status = 'CREATED';
+CREATE INDEX IF NOT EXISTS tender_submission_deadline_idx ON tender (submission_deadline) WHERE status = 'PUBLISHED';
@@ -0,0 +1,12 @@
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS delivery_terms;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS currency;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS price;
+
+ALTER TABLE bids DROP CONSTRAINT IF EXISTS bids_price_check;
+ALTER TABLE bids DROP COLUMN IF EXISTS delivery_terms;
+ALTER TABLE bids DROP COLUMN IF EXISTS currency;
+ALTER TABLE bids DROP COLUMN IF EXISTS price;
+
+ALTER TABLE tender DROP CONSTRAINT IF EXISTS tender_budget_currency_check;
+ALTER TABLE tender DROP COLUMN IF EXISTS currency;
+ALTER TABLE tender DROP COLUMN IF EXISTS budget;
@@ -0,0 +1,18 @@
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS budget NUMERIC(15, 2) CHECK (budget > 0);  -- максимальный бюджет тендера
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS currency VARCHAR(3);  -- валюта бюджета, задается вместе с бюджетом
+ALTER TABLE tender ADD CONSTRAINT tender_budget_currency_check CHECK ((budget IS NULL) = (currency IS NULL));
+
+-- Предложениям, созданным до появления цены, проставляются нулевая цена и пустые условия
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS price NUMERIC(15, 2);
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS delivery_terms TEXT;
+UPDATE bids SET price = 0, currency = 'RUB', delivery_terms = '' WHERE price IS NULL;
+ALTER TABLE bids ALTER COLUMN price SET NOT NULL;
+ALTER TABLE bids ALTER COLUMN currency SET NOT NULL;
+ALTER TABLE bids ALTER COLUMN delivery_terms SET NOT NULL;
+ALTER TABLE bids ADD CONSTRAINT bids_price_check CHECK (price >= 0);
+
+-- Цена и условия входят в версии предложения; у старых версий они не заданы
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS price NUMERIC(15, 2);
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS delivery_terms TEXT;
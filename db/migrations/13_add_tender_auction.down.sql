@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS bids_tender_price_idx;
+ALTER TABLE tender DROP CONSTRAINT IF EXISTS tender_auction_check;
+ALTER TABLE tender DROP COLUMN IF EXISTS auction_extension_minutes;
+ALTER TABLE tender DROP COLUMN IF EXISTS auction_step;
+ALTER TABLE tender DROP COLUMN IF EXISTS tender_type;
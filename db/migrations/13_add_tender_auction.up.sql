@@ -0,0 +1,11 @@
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS tender_type VARCHAR(20) NOT NULL DEFAULT 'standard'
+    CHECK (tender_type IN ('standard', 'auction'));  -- auction - обратный аукцион, окно торгов заканчивается в submission_deadline
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS auction_step NUMERIC(15, 2) CHECK (auction_step > 0);  -- минимальный шаг снижения цены
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS auction_extension_minutes INTEGER CHECK (auction_extension_minutes > 0);  -- продление торгов при ставке в последние минуты
+ALTER TABLE tender ADD CONSTRAINT tender_auction_check CHECK (
+    tender_type = 'standard'
+    OR (auction_step IS NOT NULL AND auction_extension_minutes IS NOT NULL
+        AND budget IS NOT NULL AND submission_deadline IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS bids_tender_price_idx ON bids (tender_id, price);
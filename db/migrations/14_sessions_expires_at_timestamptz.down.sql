@@ -0,0 +1,2 @@
+ALTER TABLE sessions ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE sessions ALTER COLUMN expires_at TYPE TIMESTAMP;
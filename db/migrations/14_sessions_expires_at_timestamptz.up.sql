@@ -0,0 +1,5 @@
+-- Срок сессии хранился как время без часового пояса в зоне приложения, перевести
+-- его однозначно нельзя: действующие сессии удаляются, пользователи входят заново
+DELETE FROM sessions;
+ALTER TABLE sessions ALTER COLUMN expires_at TYPE TIMESTAMPTZ;
+ALTER TABLE sessions ALTER COLUMN created_at TYPE TIMESTAMPTZ;
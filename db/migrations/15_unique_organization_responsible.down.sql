@@ -0,0 +1 @@
+ALTER TABLE organization_responsible DROP CONSTRAINT IF EXISTS organization_responsible_organization_user_key;
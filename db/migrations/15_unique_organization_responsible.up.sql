@@ -0,0 +1,16 @@
+-- Повторные назначения, созданные параллельными запросами: тендеры переводятся
+-- на самую раннюю запись (responsible_id ссылается с ON DELETE CASCADE), дубликаты удаляются
+WITH ranked AS (
+    SELECT id, FIRST_VALUE(id) OVER (PARTITION BY organization_id, user_id ORDER BY id) AS kept_id
+    FROM organization_responsible
+)
+UPDATE tender SET responsible_id = ranked.kept_id
+FROM ranked
+WHERE tender.responsible_id = ranked.id AND ranked.id <> ranked.kept_id;
+
+DELETE FROM organization_responsible orp
+USING organization_responsible kept
+WHERE kept.organization_id = orp.organization_id AND kept.user_id = orp.user_id AND kept.id < orp.id;
+
+ALTER TABLE organization_responsible
+    ADD CONSTRAINT organization_responsible_organization_user_key UNIQUE (organization_id, user_id);
@@ -0,0 +1,10 @@
+CREATE TABLE bid_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL REFERENCES bids(id) ON DELETE CASCADE,  -- связь с предложением
+    version INTEGER NOT NULL,  -- номер сохраненной версии
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL,
+    changed_by UUID REFERENCES employee(id) ON DELETE SET NULL,  -- кто заменил эту версию новой
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, version)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tender_versions;
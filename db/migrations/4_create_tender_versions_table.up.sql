@@ -0,0 +1,11 @@
+CREATE TABLE tender_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,  -- связь с тендером
+    version INTEGER NOT NULL,  -- номер сохраненной версии
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL,
+    service_type VARCHAR(50) NOT NULL,
+    changed_by UUID REFERENCES employee(id) ON DELETE SET NULL,  -- кто заменил эту версию новой
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, version)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE bid_decisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL REFERENCES bids(id) ON DELETE CASCADE,  -- связь с предложением
+    user_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,  -- ответственный, принявший решение
+    decision VARCHAR(20) NOT NULL CHECK (decision IN ('Approved', 'Rejected')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, user_id)  -- одно решение от каждого ответственного
+);
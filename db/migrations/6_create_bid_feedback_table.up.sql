@@ -0,0 +1,7 @@
+CREATE TABLE bid_feedback (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL REFERENCES bids(id) ON DELETE CASCADE,  -- связь с предложением
+    user_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,  -- ответственный, оставивший отзыв
+    description VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS sessions;
+
+ALTER TABLE employee DROP COLUMN IF EXISTS password_hash;
@@ -0,0 +1,10 @@
+ALTER TABLE employee ADD COLUMN IF NOT EXISTS password_hash VARCHAR(100);  -- bcrypt-хеш пароля, NULL - вход запрещен
+
+CREATE TABLE sessions (
+    token_hash VARCHAR(64) PRIMARY KEY,  -- SHA-256 от токена, сам токен не хранится
+    user_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,  -- владелец сессии
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id);
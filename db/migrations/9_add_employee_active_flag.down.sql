@@ -0,0 +1 @@
+ALTER TABLE employee DROP COLUMN IF EXISTS is_active;
@@ -0,0 +1 @@
+ALTER TABLE employee ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;  -- деактивированный сотрудник не может действовать, история сохраняется
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"avito-project/models"
+	"avito-project/response"
+
+	"github.com/gorilla/mux"
+)
+
+// defaultAuctionExtension - продление торгов в минутах, если auctionExtensionMinutes не задан
+const defaultAuctionExtension = 5
+
+// GetTenderAuctionHandler: Текущее состояние обратного аукциона - лучшая цена,
+// количество ставок и время окончания торгов. Участники опрашивают его во время торгов.
+func GetTenderAuctionHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	tenderID := mux.Vars(r)["tenderId"]
+
+	log.Printf("GetTenderAuctionHandler: Getting auction state for tender %s", tenderID)
+
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "GetTenderAuctionHandler")
+	if !ok {
+		return
+	}
+
+	// Состояние аукциона видно тем, кому виден тендер
+	visible, err := Tenders.IsVisible(ctx, tenderID, user.ID)
+	if err != nil {
+		log.Printf("GetTenderAuctionHandler: Failed to check tender existence: %v", err)
+		response.DBError(w, err, "Failed to check tender existence")
+		return
+	}
+	if !visible {
+		log.Printf("GetTenderAuctionHandler: Tender not found or not published: %s", tenderID)
+		response.Error(w, http.StatusNotFound, response.CodeTenderNotFound, "Tender not found")
+		return
+	}
+
+	state, err := Tenders.AuctionState(ctx, tenderID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("GetTenderAuctionHandler: Tender not found: %s", tenderID)
+		response.Error(w, http.StatusNotFound, response.CodeTenderNotFound, "Tender not found")
+		return
+	}
+	if errors.Is(err, models.ErrNotAuction) {
+		log.Printf("GetTenderAuctionHandler: Tender %s is not an auction", tenderID)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Tender is not an auction")
+		return
+	}
+	if err != nil {
+		log.Printf("GetTenderAuctionHandler: Failed to retrieve auction state: %v", err)
+		response.DBError(w, err, "Failed to retrieve auction state")
+		return
+	}
+
+	// Ответ не кэшируется: лучшая цена меняется с каждой ставкой
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(state)
+
+	log.Printf("GetTenderAuctionHandler: Successfully retrieved auction state in %v", time.Since(start))
+}
+
+// checkTenderAuction проверяет тип тендера и параметры аукциона. Аукциону нужны
+// бюджет (начальная цена), срок окончания торгов и шаг снижения цены меньше бюджета.
+// Возвращает описание ошибки или пустую строку.
+func checkTenderAuction(tenderType string, deadline *time.Time, budget, step *models.Amount, extension *int) string {
+	if tenderType != models.TenderTypeAuction {
+		if step != nil || extension != nil {
+			return "Auction parameters are allowed only for auction tenders"
+		}
+		return ""
+	}
+	if budget == nil || deadline == nil || step == nil {
+		return "Auction requires budget, submission deadline and auction step"
+	}
+	if reason := checkAmount("Auction step", *step); reason != "" {
+		return reason
+	}
+	if *step >= *budget {
+		return "Auction step must be less than budget"
+	}
+	return ""
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"avito-project/models"
+	"avito-project/response"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Sessions - репозиторий сессий, инициализируется при запуске сервера
+var Sessions models.SessionRepository
+
+// SessionTTL - время жизни выданного токена
+var SessionTTL = 24 * time.Hour
+
+// tokenBytes - длина случайной части токена в байтах
+const tokenBytes = 32
+
+// dummyPasswordHash - bcrypt-хеш со стоимостью по умолчанию, с которым сверяется пароль,
+// если у пользователя нет хеша: ответ для неизвестного имени не приходит быстрее
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+type contextKey int
+
+const (
+	identityContextKey contextKey = iota
+	tokenHashContextKey
+)
+
+// Identity - аутентифицированный пользователь запроса и организации,
+// за которые он отвечает. Определяется один раз в AuthMiddleware.
+type Identity struct {
+	User *models.Employee
+	// Responsibilities: идентификатор организации -> идентификатор записи organization_responsible
+	Responsibilities map[string]string
+}
+
+// ResponsibleID возвращает запись organization_responsible пользователя
+// в организации и признак того, что он за нее отвечает
+func (i *Identity) ResponsibleID(organizationID string) (string, bool) {
+	if i == nil {
+		return "", false
+	}
+	responsibleID, ok := i.Responsibilities[organizationID]
+	return responsibleID, ok
+}
+
+// hashToken возвращает SHA-256 токена, под которым сессия хранится в базе
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newToken генерирует случайный непрозрачный токен
+func newToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// AuthMiddleware определяет пользователя по заголовку Authorization: Bearer <token>,
+// загружает организации, за которые он отвечает, и сохраняет их в контексте запроса.
+// Запрос без заголовка передается дальше анонимным, обработчики сами решают,
+// требуется ли им пользователь.
+func AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || token == "" {
+			log.Println("AuthMiddleware: Invalid authorization header")
+			response.Error(w, http.StatusUnauthorized, response.CodeUnauthorized, "Invalid authorization header")
+			return
+		}
+
+		tokenHash := hashToken(token)
+		user, err := Sessions.GetUser(r.Context(), tokenHash)
+		if errors.Is(err, models.ErrNotFound) {
+			log.Println("AuthMiddleware: Invalid or expired token")
+			response.Error(w, http.StatusUnauthorized, response.CodeUnauthorized, "Invalid or expired token")
+			return
+		}
+		if err != nil {
+			log.Printf("AuthMiddleware: Failed to retrieve session: %v", err)
+			response.DBError(w, err, "Failed to retrieve session")
+			return
+		}
+
+		// Деактивированный сотрудник не может действовать от своего имени
+		if !user.IsActive {
+			log.Printf("AuthMiddleware: User %s is deactivated", user.Username)
+			response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "User is deactivated")
+			return
+		}
+
+		responsibilities, err := Organizations.ListResponsibilities(r.Context(), user.ID)
+		if err != nil {
+			log.Printf("AuthMiddleware: Failed to retrieve user organizations: %v", err)
+			response.DBError(w, err, "Failed to retrieve user organizations")
+			return
+		}
+
+		identity := &Identity{User: user, Responsibilities: responsibilities}
+		ctx := context.WithValue(r.Context(), identityContextKey, identity)
+		ctx = context.WithValue(ctx, tokenHashContextKey, tokenHash)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// identityFromContext возвращает данные аутентифицированного пользователя запроса или nil
+func identityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityContextKey).(*Identity)
+	return identity
+}
+
+// userFromContext возвращает аутентифицированного пользователя запроса или nil
+func userFromContext(ctx context.Context) *models.Employee {
+	if identity := identityFromContext(ctx); identity != nil {
+		return identity.User
+	}
+	return nil
+}
+
+// currentUser возвращает аутентифицированного пользователя запроса.
+// Если токен не передан, отправляет 401 и возвращает false.
+func currentUser(ctx context.Context, w http.ResponseWriter, handler string) (*models.Employee, bool) {
+	user := userFromContext(ctx)
+	if user == nil {
+		log.Printf("%s: Authentication required", handler)
+		response.Error(w, http.StatusUnauthorized, response.CodeUnauthorized, "Authentication required")
+		return nil, false
+	}
+	return user, true
+}
+
+// LoginHandler проверяет имя пользователя и пароль и выдает токен сессии
+func LoginHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	log.Println("LoginHandler: Issuing session token")
+
+	var input struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("LoginHandler: Invalid input: %v", err)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
+		return
+	}
+
+	// Неизвестный пользователь, незаданный и неверный пароль неразличимы для клиента,
+	// в том числе по времени ответа: без хеша пароль сверяется с dummyPasswordHash
+	user, err := Employees.GetByUsername(ctx, input.Username)
+	var passwordHash string
+	if err == nil {
+		passwordHash, err = Employees.GetPasswordHash(ctx, user.ID)
+	}
+	if err == nil {
+		err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(input.Password))
+	} else if errors.Is(err, models.ErrNotFound) {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(input.Password))
+	}
+	if errors.Is(err, models.ErrNotFound) || errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		log.Printf("LoginHandler: Invalid credentials for user %s", input.Username)
+		response.Error(w, http.StatusUnauthorized, response.CodeUnauthorized, "Invalid username or password")
+		return
+	}
+	if err != nil {
+		log.Printf("LoginHandler: Failed to check credentials: %v", err)
+		response.DBError(w, err, "Failed to check credentials")
+		return
+	}
+
+	if !user.IsActive {
+		log.Printf("LoginHandler: User %s is deactivated", user.Username)
+		response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "User is deactivated")
+		return
+	}
+
+	token, err := newToken()
+	if err != nil {
+		log.Printf("LoginHandler: Failed to generate token: %v", err)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternalError, "Failed to generate token")
+		return
+	}
+
+	expiresAt, err := Sessions.Create(ctx, hashToken(token), user.ID, SessionTTL)
+	if err != nil {
+		log.Printf("LoginHandler: Failed to create session: %v", err)
+		response.DBError(w, err, "Failed to create session")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"token":     token,
+		"expiresAt": expiresAt.UTC().Format(time.RFC3339),
+	})
+
+	log.Printf("LoginHandler: Session created for user %s in %v", user.Username, time.Since(start))
+}
+
+// LogoutHandler завершает сессию, по токену которой выполнен запрос
+func LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+
+	user, ok := currentUser(ctx, w, "LogoutHandler")
+	if !ok {
+		return
+	}
+
+	tokenHash, _ := ctx.Value(tokenHashContextKey).(string)
+	err := Sessions.Delete(ctx, tokenHash)
+	if err != nil {
+		log.Printf("LogoutHandler: Failed to delete session: %v", err)
+		response.DBError(w, err, "Failed to delete session")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+
+	log.Printf("LogoutHandler: Session closed for user %s in %v", user.Username, time.Since(start))
+}
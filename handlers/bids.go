@@ -1,104 +1,129 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
-	"avito-project/db"
+	"avito-project/models"
+	"avito-project/response"
+
+	"github.com/gorilla/mux"
 )
 
 // CreateBidHandler обрабатывает создание нового предложения
 func CreateBidHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
+	ctx := r.Context()
 	log.Println("CreateBidHandler: Creating a new bid")
 
 	// Структура для данных предложения
-	var bid struct {
+	var input struct {
 		Name        string `json:"name"`
 		Description string `json:"description"`
 		TenderID    string `json:"tenderId"`
 		AuthorType  string `json:"authorType"`
 		AuthorID    string `json:"authorId"`
+		// Цена, валюта и условия поставки обязательны
+		Price         models.Amount `json:"price"`
+		Currency      string        `json:"currency"`
+		DeliveryTerms string        `json:"deliveryTerms"`
 	}
 
 	// Декодирование JSON тела запроса
-	err := json.NewDecoder(r.Body).Decode(&bid)
+	err := json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
 		log.Printf("CreateBidHandler: Invalid input: %v", err)
-		http.Error(w, "Invalid input", http.StatusBadRequest)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
 		return
 	}
 
-	conn := db.GetConnection()
+	if reason := checkAmount("Price", input.Price); reason != "" {
+		log.Printf("CreateBidHandler: Invalid price: %s", reason)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, reason)
+		return
+	}
 
-	// Проверка существования пользователя
-	var userExists bool
-	err = conn.QueryRow(context.Background(), "SELECT EXISTS(SELECT 1 FROM employee WHERE id = $1)", bid.AuthorID).Scan(&userExists)
-	if err != nil {
-		log.Printf("CreateBidHandler: Failed to check user existence: %v", err)
-		http.Error(w, "Failed to check user existence", http.StatusInternalServerError)
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "CreateBidHandler")
+	if !ok {
 		return
 	}
-	if !userExists {
-		log.Printf("CreateBidHandler: User not found with id: %s", bid.AuthorID)
-		http.Error(w, "User not found", http.StatusUnauthorized) // 401 ошибка
+
+	// Пользователь может подать предложение от своего имени
+	// или от организации, за которую он отвечает
+	if input.AuthorType == models.AuthorTypeOrganization {
+		// Организация-автор должна существовать
+		_, err = Organizations.GetByID(ctx, input.AuthorID)
+		if errors.Is(err, models.ErrNotFound) {
+			log.Printf("CreateBidHandler: Organization not found: %s", input.AuthorID)
+			response.Error(w, http.StatusNotFound, response.CodeOrganizationNotFound, "Organization not found")
+			return
+		}
+		if err != nil {
+			log.Printf("CreateBidHandler: Failed to retrieve organization: %v", err)
+			response.DBError(w, err, "Failed to retrieve organization")
+			return
+		}
+		if _, ok = identityFromContext(ctx).ResponsibleID(input.AuthorID); !ok {
+			log.Printf("CreateBidHandler: User %s is not responsible for organization %s", user.Username, input.AuthorID)
+			response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "User is not responsible for this organization")
+			return
+		}
+	} else if input.AuthorID != user.ID {
+		log.Printf("CreateBidHandler: User %s cannot create bid on behalf of %s", user.Username, input.AuthorID)
+		response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "User cannot create bid on behalf of another user")
 		return
 	}
 
-	// Проверка существования тендера
-	var tenderExists bool
-	err = conn.QueryRow(context.Background(), "SELECT EXISTS(SELECT 1 FROM tender WHERE id=$1)", bid.TenderID).Scan(&tenderExists)
+	// Проверка существования тендера и его видимости для пользователя
+	visible, err := Tenders.IsVisible(ctx, input.TenderID, user.ID)
 	if err != nil {
 		log.Printf("CreateBidHandler: Failed to check tender existence: %v", err)
-		http.Error(w, "Failed to check tender existence", http.StatusInternalServerError)
+		response.DBError(w, err, "Failed to check tender existence")
 		return
 	}
-	if !tenderExists {
-		log.Println("CreateBidHandler: Tender not found")
-		http.Error(w, "Tender not found", http.StatusNotFound)
+	if !visible {
+		log.Println("CreateBidHandler: Tender not found or not published")
+		response.Error(w, http.StatusNotFound, response.CodeTenderNotFound, "Tender not found")
 		return
 	}
 
-	// Статус по умолчанию для нового предложения
-	status := "CREATED"
-	version := 1
-	createdAt := time.Now()
-
 	// Вставка нового предложения в базу данных
-	query := `
-		INSERT INTO bids (name, description, status, tender_id, author_type, author_id, version, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
-		RETURNING id, created_at
-	`
-
-	var bidID string
-	err = conn.QueryRow(context.Background(), query,
-		bid.Name, bid.Description, status, bid.TenderID, bid.AuthorType, bid.AuthorID, version).
-		Scan(&bidID, &createdAt)
+	bid := models.Bid{
+		Name:        input.Name,
+		Description: input.Description,
+		TenderID:    input.TenderID,
+		AuthorType:  input.AuthorType,
+		AuthorID:    input.AuthorID,
+		// Цена проверяется по бюджету тендера при сохранении
+		Price:         input.Price,
+		Currency:      input.Currency,
+		DeliveryTerms: input.DeliveryTerms,
+	}
+	err = Bids.Create(ctx, &bid)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Println("CreateBidHandler: Tender not found")
+		response.Error(w, http.StatusNotFound, response.CodeTenderNotFound, "Tender not found")
+		return
+	}
+	if bidLifecycleError(w, "CreateBidHandler", err) {
+		return
+	}
 	if err != nil {
 		log.Printf("CreateBidHandler: Failed to create bid: %v", err)
-		http.Error(w, "Failed to create bid", http.StatusInternalServerError)
+		response.DBError(w, err, "Failed to create bid")
 		return
 	}
 
 	// Формируем успешный ответ
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"id":          bidID,
-		"name":        bid.Name,
-		"description": bid.Description,
-		"status":      status,
-		"tenderId":    bid.TenderID,
-		"authorType":  bid.AuthorType,
-		"authorId":    bid.AuthorID,
-		"version":     version,
-		"createdAt":   createdAt.Format(time.RFC3339),
-	})
+	json.NewEncoder(w).Encode(bid)
 
 	log.Printf("CreateBidHandler: Bid created successfully in %v", time.Since(start))
 }
@@ -106,94 +131,73 @@ func CreateBidHandler(w http.ResponseWriter, r *http.Request) {
 // GetUserBidsHandler обрабатывает получение списка предложений текущего пользователя с пагинацией
 func GetUserBidsHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
+	ctx := r.Context()
 
-	// Получение параметра username из query
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		log.Println("GetUserBidsHandler: Username is required")
-		http.Error(w, "Username is required", http.StatusBadRequest)
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "GetUserBidsHandler")
+	if !ok {
 		return
 	}
 
 	// Получение параметров пагинации: limit и offset
-	limitParam := r.URL.Query().Get("limit")
-	offsetParam := r.URL.Query().Get("offset")
-
-	// Установка значений по умолчанию для limit и offset
-	limit := 10
-	offset := 0
-
-	// Если переданы параметры пагинации, пытаемся их конвертировать
-	if limitParam != "" {
-		parsedLimit, err := strconv.Atoi(limitParam)
-		if err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-		}
-	}
-	if offsetParam != "" {
-		parsedOffset, err := strconv.Atoi(offsetParam)
-		if err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
+	limit, offset := parsePagination(r)
+
+	log.Printf("GetUserBidsHandler: Retrieving bids for user %s with limit %d and offset %d", user.Username, limit, offset)
+
+	// Получение списка предложений с использованием пагинации
+	bids, err := Bids.ListByAuthor(ctx, user.ID, limit, offset)
+	if err != nil {
+		log.Printf("GetUserBidsHandler: Failed to retrieve bids: %v", err)
+		response.DBError(w, err, "Failed to retrieve bids")
+		return
 	}
 
-	log.Printf("GetUserBidsHandler: Retrieving bids for user %s with limit %d and offset %d", username, limit, offset)
+	// Возвращаем список предложений
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bids)
 
-	conn := db.GetConnection()
+	log.Printf("GetUserBidsHandler: Successfully retrieved bids for user %s in %v", user.Username, time.Since(start))
+}
 
-	// Проверка, существует ли пользователь
-	var userID string
-	err := conn.QueryRow(context.Background(), "SELECT id FROM employee WHERE username = $1", username).Scan(&userID)
-	if err != nil {
-		log.Printf("GetUserBidsHandler: User not found: %v", err)
-		http.Error(w, "User not found", http.StatusUnauthorized) // 401 ошибка
+// GetBidsForTenderHandler обрабатывает получение списка предложений для конкретного тендера
+func GetBidsForTenderHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+
+	// Получение параметра tenderId из URL path
+	tenderID := mux.Vars(r)["tenderId"]
+	if tenderID == "" {
+		log.Println("GetBidsForTenderHandler: Tender ID is required")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Tender ID is required")
 		return
 	}
 
-	// Запрос для получения списка предложений с использованием пагинации
-	rows, err := conn.Query(context.Background(), `
-		SELECT id, name, description, status, tender_id, author_type, version, created_at
-		FROM bids
-		WHERE author_id = $1
-		ORDER BY name ASC
-		LIMIT $2 OFFSET $3`, userID, limit, offset)
-	if err != nil {
-		log.Printf("GetUserBidsHandler: Failed to retrieve bids: %v", err)
-		http.Error(w, "Failed to retrieve bids", http.StatusInternalServerError)
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "GetBidsForTenderHandler")
+	if !ok {
 		return
 	}
-	defer rows.Close()
 
-	var bids []map[string]interface{}
+	// Получение параметров пагинации: limit и offset, и порядка сортировки
+	limit, offset := parsePagination(r)
+	sortBy := r.URL.Query().Get("sort_by")
+	if sortBy == "" {
+		sortBy = models.BidSortName
+	}
+
+	log.Printf("GetBidsForTenderHandler: Retrieving bids for tender %s and user %s sorted by %s with limit %d and offset %d", tenderID, user.Username, sortBy, limit, offset)
 
-	// Обрабатываем строки результата
-	for rows.Next() {
-		var id, name, description, status, tenderID, authorType string
-		var version int
-		var createdAt time.Time
-		err = rows.Scan(&id, &name, &description, &status, &tenderID, &authorType, &version, &createdAt)
-		if err != nil {
-			log.Printf("GetUserBidsHandler: Failed to scan bid: %v", err)
-			http.Error(w, "Failed to scan bids", http.StatusInternalServerError)
-			return
-		}
-		bids = append(bids, map[string]interface{}{
-			"id":          id,
-			"name":        name,
-			"description": description,
-			"status":      status,
-			"tenderId":    tenderID,
-			"authorType":  authorType,
-			"version":     version,
-			"createdAt":   createdAt.Format(time.RFC3339),
-		})
+	// Проверка существования тендера и прав пользователя
+	if _, ok = lookupResponsibleTender(ctx, w, "GetBidsForTenderHandler", tenderID); !ok {
+		return
 	}
 
-	// Если не найдено предложений
-	if len(bids) == 0 {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode([]map[string]interface{}{})
+	// Получение списка предложений для тендера с учетом пагинации
+	bids, err := Bids.ListByTender(ctx, tenderID, sortBy, limit, offset)
+	if err != nil {
+		log.Printf("GetBidsForTenderHandler: Failed to retrieve bids: %v", err)
+		response.DBError(w, err, "Failed to retrieve bids")
 		return
 	}
 
@@ -202,259 +206,383 @@ func GetUserBidsHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(bids)
 
-	log.Printf("GetUserBidsHandler: Successfully retrieved bids for user %s in %v", username, time.Since(start))
+	log.Printf("GetBidsForTenderHandler: Successfully retrieved bids for tender %s in %v", tenderID, time.Since(start))
 }
 
-// GetBidsForTenderHandler обрабатывает получение списка предложений для конкретного тендера
-func GetBidsForTenderHandler(w http.ResponseWriter, r *http.Request) {
+func SubmitBidDecisionHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
+	ctx := r.Context()
+	log.Println("SubmitBidDecisionHandler: Processing decision submission")
 
-	// Получение параметра tenderId из URL path
-	tenderID := r.URL.Path[len("/api/bids/") : len(r.URL.Path)-len("/list")]
-	if tenderID == "" {
-		log.Println("GetBidsForTenderHandler: Tender ID is required")
-		http.Error(w, "Tender ID is required", http.StatusBadRequest)
+	// Получение параметра bidId из URL path
+	bidID := mux.Vars(r)["bidId"]
+	if bidID == "" {
+		log.Println("SubmitBidDecisionHandler: Bid ID is required")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Bid ID is required")
 		return
 	}
 
-	// Получение параметра username из query
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		log.Println("GetBidsForTenderHandler: Username is required")
-		http.Error(w, "Username is required", http.StatusBadRequest)
+	// Получение параметров из запроса
+	decision := r.URL.Query().Get("decision")
+
+	if decision == "" {
+		log.Println("SubmitBidDecisionHandler: Missing required parameters")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Missing required parameters")
 		return
 	}
 
-	// Получение параметров пагинации: limit и offset
-	limitParam := r.URL.Query().Get("limit")
-	offsetParam := r.URL.Query().Get("offset")
-
-	// Установка значений по умолчанию для limit и offset
-	limit := 10
-	offset := 0
-
-	// Если переданы параметры пагинации, пытаемся их конвертировать
-	if limitParam != "" {
-		parsedLimit, err := strconv.Atoi(limitParam)
-		if err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-		}
+	// Валидация решения
+	if decision != models.DecisionApproved && decision != models.DecisionRejected {
+		log.Printf("SubmitBidDecisionHandler: Invalid decision: %s", decision)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid decision value")
+		return
 	}
-	if offsetParam != "" {
-		parsedOffset, err := strconv.Atoi(offsetParam)
-		if err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
+
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "SubmitBidDecisionHandler")
+	if !ok {
+		return
+	}
+
+	// Сохранение решения и применение итогов голосования. Проверка прав,
+	// блокировка тендера и его закрытие выполняются в одной транзакции:
+	// решение может принять любой ответственный за организацию тендера
+	bid, tally, err := Bids.SubmitDecision(ctx, bidID, user.ID, decision)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("SubmitBidDecisionHandler: Bid not found: %s", bidID)
+		response.Error(w, http.StatusNotFound, response.CodeBidNotFound, "Bid not found")
+		return
+	}
+	if errors.Is(err, models.ErrForbidden) {
+		log.Printf("SubmitBidDecisionHandler: User %s is not responsible for tender of bid %s", user.Username, bidID)
+		response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "User is not responsible for this tender")
+		return
+	}
+	if bidLifecycleError(w, "SubmitBidDecisionHandler", err) || tenderTransitionError(w, "SubmitBidDecisionHandler", err) {
+		return
+	}
+	if err != nil {
+		log.Printf("SubmitBidDecisionHandler: Failed to submit decision: %v", err)
+		response.DBError(w, err, "Failed to submit decision")
+		return
 	}
 
-	log.Printf("GetBidsForTenderHandler: Retrieving bids for tender %s and user %s with limit %d and offset %d", tenderID, username, limit, offset)
+	log.Printf("SubmitBidDecisionHandler: Bid %s has %d approvals of quorum %d and %d rejections", bidID, tally.Approvals, tally.Quorum, tally.Rejections)
 
-	conn := db.GetConnection()
+	// Ответ с данными обновленного предложения
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bid)
 
-	// Проверка, существует ли пользователь
-	var userID string
-	err := conn.QueryRow(context.Background(), "SELECT id FROM employee WHERE username = $1", username).Scan(&userID)
-	if err != nil {
-		log.Printf("GetBidsForTenderHandler: User not found: %v", err)
-		http.Error(w, "User not found", http.StatusUnauthorized)
+	log.Printf("SubmitBidDecisionHandler: Decision submitted successfully for bid %s in %v", bidID, time.Since(start))
+}
+
+// GetBidStatusHandler: Получить статус предложения по ID
+func GetBidStatusHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	bidID := vars["bidId"]
+
+	log.Printf("GetBidStatusHandler: Getting status for bid %s", bidID)
+
+	// Пользователь определяется по токену
+	_, ok := currentUser(ctx, w, "GetBidStatusHandler")
+	if !ok {
 		return
 	}
 
-	// Проверка, существует ли тендер
-	var existingTenderID string
-	err = conn.QueryRow(context.Background(), "SELECT id FROM tender WHERE id = $1", tenderID).Scan(&existingTenderID)
-	if err != nil {
-		log.Printf("GetBidsForTenderHandler: Tender not found: %v", err)
-		http.Error(w, "Tender not found", http.StatusNotFound)
+	// Получение предложения и проверка прав пользователя
+	bid, ok := lookupAccessibleBid(ctx, w, "GetBidStatusHandler", bidID)
+	if !ok {
 		return
 	}
 
-	// Проверка прав пользователя (например, является ли он ответственным за этот тендер)
-	var isResponsible bool
-	err = conn.QueryRow(context.Background(), `
-		SELECT EXISTS (
-			SELECT 1 
-			FROM organization_responsible AS orp
-			INNER JOIN tender ON tender.organization_id = orp.organization_id
-			WHERE orp.user_id = $1 AND tender.id = $2
-		)`, userID, tenderID).Scan(&isResponsible)
-	if err != nil {
-		log.Printf("GetBidsForTenderHandler: Error checking user permissions: %v", err)
-		http.Error(w, "Error checking user permissions", http.StatusInternalServerError)
+	// Успешный ответ
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"status": bid.Status,
+	})
+
+	log.Printf("GetBidStatusHandler: Successfully retrieved status in %v", time.Since(start))
+}
+
+// UpdateBidStatusHandler: Изменить статус предложения по ID
+func UpdateBidStatusHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	bidID := vars["bidId"]
+	status := strings.ToUpper(r.URL.Query().Get("status"))
+
+	log.Printf("UpdateBidStatusHandler: Updating status for bid %s", bidID)
+
+	// Пользователь определяется по токену
+	_, ok := currentUser(ctx, w, "UpdateBidStatusHandler")
+	if !ok {
 		return
 	}
 
-	if !isResponsible {
-		log.Printf("GetBidsForTenderHandler: User %s does not have permission for tender %s", username, tenderID)
-		http.Error(w, "User does not have permission for this tender", http.StatusForbidden)
+	// Проверка существования предложения и прав пользователя
+	if _, ok = lookupAccessibleBid(ctx, w, "UpdateBidStatusHandler", bidID); !ok {
 		return
 	}
 
-	// Запрос списка предложений для тендера с учетом пагинации
-	rows, err := conn.Query(context.Background(), `
-		SELECT id, name, description, status, author_type, version, created_at
-		FROM bids
-		WHERE tender_id = $1
-		ORDER BY name ASC
-		LIMIT $2 OFFSET $3`, tenderID, limit, offset)
+	// Проверка допустимого статуса
+	if status != models.BidStatusCreated && status != models.BidStatusPublished && status != models.BidStatusCanceled {
+		log.Printf("UpdateBidStatusHandler: Invalid status value: %s", status)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid status value")
+		return
+	}
+
+	// Обновление статуса предложения
+	bid, err := Bids.UpdateStatus(ctx, bidID, status)
+	if bidLifecycleError(w, "UpdateBidStatusHandler", err) {
+		return
+	}
 	if err != nil {
-		log.Printf("GetBidsForTenderHandler: Failed to retrieve bids: %v", err)
-		http.Error(w, "Failed to retrieve bids", http.StatusInternalServerError)
+		log.Printf("UpdateBidStatusHandler: Failed to update status: %v", err)
+		response.DBError(w, err, "Failed to update status")
+		return
+	}
+
+	// Успешный ответ
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bid)
+
+	log.Printf("UpdateBidStatusHandler: Successfully updated status in %v", time.Since(start))
+}
+
+// EditBidHandler: Редактирование предложения с увеличением версии
+func EditBidHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	bidID := vars["bidId"]
+
+	log.Printf("EditBidHandler: Editing bid %s", bidID)
+
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "EditBidHandler")
+	if !ok {
 		return
 	}
-	defer rows.Close()
 
-	var bids []map[string]interface{}
+	// Проверка существования предложения и прав пользователя на его редактирование
+	if _, ok = lookupAccessibleBid(ctx, w, "EditBidHandler", bidID); !ok {
+		return
+	}
 
-	// Обработка результатов запроса
-	for rows.Next() {
-		var id, name, description, status, authorType string
-		var version int
-		var createdAt time.Time
-		err = rows.Scan(&id, &name, &description, &status, &authorType, &version, &createdAt)
-		if err != nil {
-			log.Printf("GetBidsForTenderHandler: Failed to scan bid: %v", err)
-			http.Error(w, "Failed to scan bids", http.StatusInternalServerError)
+	// Декодирование запроса
+	var update models.BidUpdate
+	err := json.NewDecoder(r.Body).Decode(&update)
+	if err != nil {
+		log.Printf("EditBidHandler: Invalid input: %v", err)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
+		return
+	}
+
+	if update.Name == nil && update.Description == nil &&
+		update.Price == nil && update.Currency == nil && update.DeliveryTerms == nil {
+		log.Println("EditBidHandler: No fields to update")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "No fields to update")
+		return
+	}
+	if update.Price != nil {
+		if reason := checkAmount("Price", *update.Price); reason != "" {
+			log.Printf("EditBidHandler: Invalid price: %s", reason)
+			response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, reason)
 			return
 		}
-		bids = append(bids, map[string]interface{}{
-			"id":          id,
-			"name":        name,
-			"description": description,
-			"status":      status,
-			"authorType":  authorType,
-			"version":     version,
-			"createdAt":   createdAt.Format(time.RFC3339),
-		})
 	}
 
-	// Если не найдено предложений
-	if len(bids) == 0 {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode([]map[string]interface{}{})
+	// Обновление предложения с сохранением предыдущей версии
+	bid, err := Bids.Edit(ctx, bidID, update, user.ID)
+	if bidLifecycleError(w, "EditBidHandler", err) {
+		return
+	}
+	if err != nil {
+		log.Printf("EditBidHandler: Failed to update bid: %v", err)
+		response.DBError(w, err, "Failed to update bid")
 		return
 	}
 
-	// Возвращаем список предложений
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(bids)
+	json.NewEncoder(w).Encode(bid)
 
-	log.Printf("GetBidsForTenderHandler: Successfully retrieved bids for tender %s in %v", tenderID, time.Since(start))
+	log.Printf("EditBidHandler: Bid updated successfully in %v", time.Since(start))
 }
 
-func SubmitBidDecisionHandler(w http.ResponseWriter, r *http.Request) {
+// RollbackBidHandler: Откат к предыдущей версии предложения
+func RollbackBidHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
-	log.Println("SubmitBidDecisionHandler: Processing decision submission")
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	bidID := vars["bidId"]
+	versionStr := vars["version"]
 
-	// Получение параметра tenderId из URL path
-	bidID := r.URL.Path[len("/api/bids/") : len(r.URL.Path)-len("/submit_decision")]
-	if bidID == "" {
-		log.Println("SubmitBidDecisionHandler: Bid ID is required")
-		http.Error(w, "Bid ID is required", http.StatusBadRequest)
+	log.Printf("RollbackBidHandler: Rolling back bid %s to version %s", bidID, versionStr)
+
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "RollbackBidHandler")
+	if !ok {
 		return
 	}
 
-	// Получение параметров из запроса
-	decision := r.URL.Query().Get("decision")
-	username := r.URL.Query().Get("username")
+	// Проверка существования предложения и прав пользователя на его откат
+	if _, ok = lookupAccessibleBid(ctx, w, "RollbackBidHandler", bidID); !ok {
+		return
+	}
 
-	if decision == "" || username == "" {
-		log.Println("SubmitBidDecisionHandler: Missing required parameters")
-		http.Error(w, "Missing required parameters", http.StatusBadRequest)
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version < 1 {
+		log.Printf("RollbackBidHandler: Invalid version format: %s", versionStr)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid version format")
 		return
 	}
 
-	// Валидация решения
-	if decision != "Approved" && decision != "Rejected" {
-		log.Printf("SubmitBidDecisionHandler: Invalid decision: %s", decision)
-		http.Error(w, "Invalid decision value", http.StatusBadRequest)
+	// Откат к указанной версии и инкремент версии
+	bid, err := Bids.Rollback(ctx, bidID, version, user.ID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("RollbackBidHandler: Version not found for bid %s and version %d", bidID, version)
+		response.Error(w, http.StatusNotFound, response.CodeVersionNotFound, "Version not found")
+		return
+	}
+	if bidLifecycleError(w, "RollbackBidHandler", err) {
+		return
+	}
+	if err != nil {
+		log.Printf("RollbackBidHandler: Failed to rollback bid: %v", err)
+		response.DBError(w, err, "Failed to rollback bid")
 		return
 	}
 
-	conn := db.GetConnection()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bid)
 
-	// Проверка существования пользователя
-	var userID string
-	err := conn.QueryRow(context.Background(), "SELECT id FROM employee WHERE username = $1", username).Scan(&userID)
-	if err != nil {
-		log.Printf("SubmitBidDecisionHandler: User not found: %v", err)
-		http.Error(w, "User not found", http.StatusUnauthorized)
+	log.Printf("RollbackBidHandler: Bid rolled back successfully in %v", time.Since(start))
+}
+
+// SubmitBidFeedbackHandler: Отзыв ответственного за организацию на предложение
+func SubmitBidFeedbackHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	bidID := vars["bidId"]
+	feedback := r.URL.Query().Get("bidFeedback")
+
+	log.Printf("SubmitBidFeedbackHandler: Submitting feedback for bid %s", bidID)
+
+	if feedback == "" {
+		log.Println("SubmitBidFeedbackHandler: Missing required parameters")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Missing required parameters")
+		return
+	}
+
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "SubmitBidFeedbackHandler")
+	if !ok {
 		return
 	}
 
 	// Проверка существования предложения
-	var currentBidStatus string
-	err = conn.QueryRow(context.Background(), "SELECT status FROM bids WHERE id = $1", bidID).Scan(&currentBidStatus)
+	bid, err := Bids.GetByID(ctx, bidID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("SubmitBidFeedbackHandler: Bid not found: %s", bidID)
+		response.Error(w, http.StatusNotFound, response.CodeBidNotFound, "Bid not found")
+		return
+	}
 	if err != nil {
-		log.Printf("SubmitBidDecisionHandler: Bid not found: %v", err)
-		http.Error(w, "Bid not found", http.StatusNotFound)
+		log.Printf("SubmitBidFeedbackHandler: Failed to retrieve bid: %v", err)
+		response.DBError(w, err, "Failed to retrieve bid")
 		return
 	}
 
-	// Проверка прав доступа
-	var isAuthorized bool
-	err = conn.QueryRow(context.Background(), `
-		SELECT EXISTS (
-			SELECT 1 FROM tender 
-			WHERE creator_id = $1 
-			AND id = (SELECT tender_id FROM bids WHERE id = $2)
-		)`, userID, bidID).Scan(&isAuthorized)
-	if err != nil || !isAuthorized {
-		log.Printf("SubmitBidDecisionHandler: User is not authorized to submit decision for this bid: %v", err)
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	// Проверка прав пользователя (является ли он ответственным за тендер предложения)
+	if _, ok = lookupResponsibleTender(ctx, w, "SubmitBidFeedbackHandler", bid.TenderID); !ok {
 		return
 	}
 
-	// Получаем tenderID
-	var tenderID string
-	err = conn.QueryRow(context.Background(), `
-		SELECT tender_id FROM bids
-		WHERE id = $1`, bidID).Scan(&tenderID)
+	// Сохранение отзыва
+	err = Bids.AddFeedback(ctx, bidID, user.ID, feedback)
 	if err != nil {
-		log.Printf("SubmitBidDecisionHandler: Tender not found: %v", err)
-		http.Error(w, "Tender not found", http.StatusForbidden)
+		log.Printf("SubmitBidFeedbackHandler: Failed to save feedback: %v", err)
+		response.DBError(w, err, "Failed to save feedback")
 		return
 	}
 
-	// Если решение "Approved", закрываем тендер
-	if decision == "Approved" {
-		_, err = conn.Exec(context.Background(), `
-			UPDATE tender
-			SET status = 'CLOSED'
-			WHERE id = $1`, tenderID)
-		if err != nil {
-			log.Printf("SubmitBidDecisionHandler: Failed to close tender: %v", err)
-			http.Error(w, "Failed to close tender", http.StatusInternalServerError)
-			return
-		}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bid)
+
+	log.Printf("SubmitBidFeedbackHandler: Feedback submitted successfully for bid %s in %v", bidID, time.Since(start))
+}
+
+// GetBidReviewsHandler обрабатывает получение отзывов на предложения автора
+// для ответственного за тендер
+func GetBidReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	tenderID := vars["tenderId"]
+	authorUsername := r.URL.Query().Get("authorUsername")
+
+	if authorUsername == "" {
+		log.Println("GetBidReviewsHandler: Missing required parameters")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Missing required parameters")
+		return
 	}
 
-	// Возвращаем обновленные данные предложения
-	var bid struct {
-		ID          string    `json:"id"`
-		Name        string    `json:"name"`
-		Description string    `json:"description"`
-		Status      string    `json:"status"`
-		Version     int       `json:"version"`
-		CreatedAt   time.Time `json:"createdAt"`
+	// Получение параметров пагинации: limit и offset
+	limit, offset := parsePagination(r)
+
+	log.Printf("GetBidReviewsHandler: Retrieving reviews of author %s for tender %s with limit %d and offset %d", authorUsername, tenderID, limit, offset)
+
+	// Запрашивающий пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "GetBidReviewsHandler"); !ok {
+		return
+	}
+
+	// Проверка существования автора предложений
+	author, ok := lookupUser(ctx, w, "GetBidReviewsHandler", authorUsername)
+	if !ok {
+		return
 	}
 
-	err = conn.QueryRow(context.Background(), `
-		SELECT id, name, description, status, version, created_at
-		FROM bids
-		WHERE id = $1`, bidID).Scan(&bid.ID, &bid.Name, &bid.Description, &bid.Status, &bid.Version, &bid.CreatedAt)
+	// Проверка существования тендера и прав пользователя
+	if _, ok = lookupResponsibleTender(ctx, w, "GetBidReviewsHandler", tenderID); !ok {
+		return
+	}
+
+	// Отзывы доступны только на авторов, создавших предложение для этого тендера
+	hasBid, err := Bids.HasAuthorBidForTender(ctx, tenderID, author.ID)
 	if err != nil {
-		log.Printf("SubmitBidDecisionHandler: Failed to retrieve updated bid: %v", err)
-		http.Error(w, "Failed to retrieve updated bid", http.StatusInternalServerError)
+		log.Printf("GetBidReviewsHandler: Failed to check author bids: %v", err)
+		response.DBError(w, err, "Failed to check author bids")
+		return
+	}
+	if !hasBid {
+		log.Printf("GetBidReviewsHandler: Author %s has no bids for tender %s", authorUsername, tenderID)
+		response.Error(w, http.StatusNotFound, response.CodeBidNotFound, "Author has no bids for this tender")
 		return
 	}
 
-	// Ответ с данными обновленного предложения
+	// Получение отзывов на все предложения автора с учетом пагинации
+	reviews, err := Bids.ListReviewsByAuthor(ctx, author.ID, limit, offset)
+	if err != nil {
+		log.Printf("GetBidReviewsHandler: Failed to retrieve reviews: %v", err)
+		response.DBError(w, err, "Failed to retrieve reviews")
+		return
+	}
+
+	// Возвращаем список отзывов
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(bid)
+	json.NewEncoder(w).Encode(reviews)
 
-	log.Printf("SubmitBidDecisionHandler: Decision submitted successfully for bid %s in %v", bidID, time.Since(start))
+	log.Printf("GetBidReviewsHandler: Successfully retrieved reviews for author %s in %v", authorUsername, time.Since(start))
 }
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"avito-project/models"
+	"avito-project/response"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minPasswordLength - минимальная длина пароля при регистрации
+const minPasswordLength = 8
+
+// lookupEmployee находит сотрудника по ID. При ошибке отправляет ответ и возвращает false.
+func lookupEmployee(ctx context.Context, w http.ResponseWriter, handler, employeeID string) (*models.Employee, bool) {
+	employee, err := Employees.GetByID(ctx, employeeID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("%s: User not found: %s", handler, employeeID)
+		response.Error(w, http.StatusNotFound, response.CodeUserNotFound, "User not found")
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("%s: Failed to retrieve user: %v", handler, err)
+		response.DBError(w, err, "Failed to retrieve user")
+		return nil, false
+	}
+	return employee, true
+}
+
+// RegisterEmployeeHandler: Регистрация нового сотрудника
+func RegisterEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	log.Println("RegisterEmployeeHandler: Registering a new employee")
+
+	var input struct {
+		Username  string  `json:"username"`
+		FirstName *string `json:"firstName"`
+		LastName  *string `json:"lastName"`
+		Password  string  `json:"password"`
+	}
+
+	// Декодирование JSON тела запроса
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("RegisterEmployeeHandler: Invalid input: %v", err)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
+		return
+	}
+
+	if len(input.Password) < minPasswordLength {
+		log.Println("RegisterEmployeeHandler: Password is too short")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Password is too short")
+		return
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("RegisterEmployeeHandler: Failed to hash password: %v", err)
+		response.Error(w, http.StatusInternalServerError, response.CodeInternalError, "Failed to register user")
+		return
+	}
+
+	employee := models.Employee{
+		Username:  input.Username,
+		FirstName: input.FirstName,
+		LastName:  input.LastName,
+	}
+	err = Employees.Create(ctx, &employee, string(passwordHash))
+	if errors.Is(err, models.ErrConflict) {
+		log.Printf("RegisterEmployeeHandler: Username already taken: %s", input.Username)
+		response.Error(w, http.StatusConflict, response.CodeConflict, "Username already taken")
+		return
+	}
+	if err != nil {
+		log.Printf("RegisterEmployeeHandler: Failed to register user: %v", err)
+		response.DBError(w, err, "Failed to register user")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(employee)
+
+	log.Printf("RegisterEmployeeHandler: Employee %s registered in %v", employee.Username, time.Since(start))
+}
+
+// GetEmployeeByUsernameHandler: Профиль сотрудника по имени пользователя
+func GetEmployeeByUsernameHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	username := r.URL.Query().Get("username")
+
+	log.Printf("GetEmployeeByUsernameHandler: Retrieving employee %s", username)
+
+	if username == "" {
+		log.Println("GetEmployeeByUsernameHandler: Username is required")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Username is required")
+		return
+	}
+
+	// Пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "GetEmployeeByUsernameHandler"); !ok {
+		return
+	}
+
+	employee, err := Employees.GetByUsername(ctx, username)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("GetEmployeeByUsernameHandler: User not found: %s", username)
+		response.Error(w, http.StatusNotFound, response.CodeUserNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		log.Printf("GetEmployeeByUsernameHandler: Failed to retrieve user: %v", err)
+		response.DBError(w, err, "Failed to retrieve user")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(employee)
+
+	log.Printf("GetEmployeeByUsernameHandler: Successfully retrieved employee in %v", time.Since(start))
+}
+
+// GetEmployeeHandler: Профиль сотрудника по ID
+func GetEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	employeeID := mux.Vars(r)["employeeId"]
+
+	log.Printf("GetEmployeeHandler: Retrieving employee %s", employeeID)
+
+	// Пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "GetEmployeeHandler"); !ok {
+		return
+	}
+
+	employee, ok := lookupEmployee(ctx, w, "GetEmployeeHandler", employeeID)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(employee)
+
+	log.Printf("GetEmployeeHandler: Successfully retrieved employee in %v", time.Since(start))
+}
+
+// EditEmployeeHandler: Изменение имени и фамилии сотрудника им самим или администратором
+func EditEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	employeeID := mux.Vars(r)["employeeId"]
+
+	log.Printf("EditEmployeeHandler: Editing employee %s", employeeID)
+
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "EditEmployeeHandler")
+	if !ok {
+		return
+	}
+
+	if user.ID != employeeID && !user.IsAdmin {
+		log.Printf("EditEmployeeHandler: User %s cannot edit employee %s", user.Username, employeeID)
+		response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "User cannot edit this employee")
+		return
+	}
+
+	if _, ok = lookupEmployee(ctx, w, "EditEmployeeHandler", employeeID); !ok {
+		return
+	}
+
+	// Декодирование запроса
+	var update models.EmployeeUpdate
+	err := json.NewDecoder(r.Body).Decode(&update)
+	if err != nil {
+		log.Printf("EditEmployeeHandler: Invalid input: %v", err)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
+		return
+	}
+
+	if update.FirstName == nil && update.LastName == nil {
+		log.Println("EditEmployeeHandler: No fields to update")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "No fields to update")
+		return
+	}
+
+	employee, err := Employees.Update(ctx, employeeID, update)
+	if err != nil {
+		log.Printf("EditEmployeeHandler: Failed to update employee: %v", err)
+		response.DBError(w, err, "Failed to update employee")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(employee)
+
+	log.Printf("EditEmployeeHandler: Employee updated successfully in %v", time.Since(start))
+}
+
+// setEmployeeActive включает или отключает сотрудника. Доступно только администратору.
+func setEmployeeActive(w http.ResponseWriter, r *http.Request, handler string, active bool) {
+	start := time.Now()
+	ctx := r.Context()
+	employeeID := mux.Vars(r)["employeeId"]
+
+	log.Printf("%s: Setting active=%t for employee %s", handler, active, employeeID)
+
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, handler)
+	if !ok {
+		return
+	}
+
+	if !user.IsAdmin {
+		log.Printf("%s: User %s is not an admin", handler, user.Username)
+		response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "Only an admin can change employee activity")
+		return
+	}
+	if user.ID == employeeID {
+		log.Printf("%s: Admin %s cannot change own activity", handler, user.Username)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Admin cannot change own activity")
+		return
+	}
+
+	employee, err := Employees.SetActive(ctx, employeeID, active)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("%s: User not found: %s", handler, employeeID)
+		response.Error(w, http.StatusNotFound, response.CodeUserNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		log.Printf("%s: Failed to update employee: %v", handler, err)
+		response.DBError(w, err, "Failed to update employee")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(employee)
+
+	log.Printf("%s: Employee %s updated in %v", handler, employee.Username, time.Since(start))
+}
+
+// DeactivateEmployeeHandler: Отключение ушедшего сотрудника без удаления его истории
+func DeactivateEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	setEmployeeActive(w, r, "DeactivateEmployeeHandler", false)
+}
+
+// ActivateEmployeeHandler: Повторное включение сотрудника
+func ActivateEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	setEmployeeActive(w, r, "ActivateEmployeeHandler", true)
+}
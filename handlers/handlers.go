@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"avito-project/models"
+	"avito-project/response"
+)
+
+// Репозитории, используемые обработчиками. Инициализируются при запуске сервера,
+// в тестах могут быть заменены фейковыми реализациями.
+var (
+	Employees     models.EmployeeRepository
+	Organizations models.OrganizationRepository
+	Tenders       models.TenderRepository
+	Bids          models.BidRepository
+)
+
+// lookupUser находит сотрудника по имени пользователя.
+// При ошибке отправляет ответ и возвращает false.
+func lookupUser(ctx context.Context, w http.ResponseWriter, handler, username string) (*models.Employee, bool) {
+	user, err := Employees.GetByUsername(ctx, username)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("%s: User not found: %s", handler, username)
+		response.Error(w, http.StatusUnauthorized, response.CodeUserNotFound, "User not found")
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("%s: Failed to retrieve user: %v", handler, err)
+		response.DBError(w, err, "Failed to retrieve user")
+		return nil, false
+	}
+	return user, true
+}
+
+// lookupResponsibleTender находит тендер и проверяет, что пользователь запроса
+// является ответственным за его организацию. При ошибке отправляет ответ и возвращает false.
+func lookupResponsibleTender(ctx context.Context, w http.ResponseWriter, handler, tenderID string) (*models.Tender, bool) {
+	tender, err := Tenders.GetByID(ctx, tenderID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("%s: Tender not found: %s", handler, tenderID)
+		response.Error(w, http.StatusNotFound, response.CodeTenderNotFound, "Tender not found")
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("%s: Failed to retrieve tender: %v", handler, err)
+		response.DBError(w, err, "Failed to retrieve tender")
+		return nil, false
+	}
+
+	// Организации пользователя загружены в AuthMiddleware
+	if _, ok := identityFromContext(ctx).ResponsibleID(tender.OrganizationID); !ok {
+		log.Printf("%s: User is not responsible for tender %s", handler, tenderID)
+		response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "User is not responsible for this tender")
+		return nil, false
+	}
+	return tender, true
+}
+
+// lookupAccessibleBid находит предложение и проверяет, что пользователь запроса является
+// его автором, ответственным за организацию-автора или за одну из организаций
+// автора-пользователя. При ошибке отправляет ответ и возвращает false.
+func lookupAccessibleBid(ctx context.Context, w http.ResponseWriter, handler, bidID string) (*models.Bid, bool) {
+	bid, err := Bids.GetByID(ctx, bidID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("%s: Bid not found: %s", handler, bidID)
+		response.Error(w, http.StatusNotFound, response.CodeBidNotFound, "Bid not found")
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("%s: Failed to retrieve bid: %v", handler, err)
+		response.DBError(w, err, "Failed to retrieve bid")
+		return nil, false
+	}
+
+	// Организации пользователя загружены в AuthMiddleware
+	identity := identityFromContext(ctx)
+	if !canAccessBid(identity, bid) {
+		log.Printf("%s: User does not have permission for bid %s", handler, bidID)
+		response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "User does not have permission for this bid")
+		return nil, false
+	}
+	return bid, true
+}
+
+// canAccessBid проверяет доступ пользователя к предложению без обращения к базе
+func canAccessBid(identity *Identity, bid *models.Bid) bool {
+	if identity == nil {
+		return false
+	}
+	if bid.AuthorID == identity.User.ID {
+		return true
+	}
+	if _, ok := identity.ResponsibleID(bid.AuthorID); ok {
+		return true
+	}
+	for _, organizationID := range bid.AuthorOrganizationIDs {
+		if _, ok := identity.ResponsibleID(organizationID); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleViolation описывает ответ на ошибку правил предметной области из пакета models
+type ruleViolation struct {
+	err     error
+	status  int
+	code    string
+	message string
+}
+
+// deadlinePassed - истекший срок подачи предложений: одинаково отвечают
+// и переходы тендера, и операции с предложениями
+var deadlinePassed = ruleViolation{models.ErrDeadlinePassed, http.StatusConflict, response.CodeConflict, "Submission deadline has passed"}
+
+// tenderTransitionViolations - ошибки конечного автомата статусов тендера
+var tenderTransitionViolations = []ruleViolation{
+	{models.ErrReasonRequired, http.StatusBadRequest, response.CodeInvalidRequest, "Reason is required for this status change"},
+	{models.ErrInvalidTransition, http.StatusConflict, response.CodeConflict, "Status transition is not allowed"},
+	{models.ErrTenderAwarded, http.StatusConflict, response.CodeConflict, "Tender has an approved bid"},
+	deadlinePassed,
+}
+
+// bidLifecycleViolations - нарушения правил жизненного цикла предложения,
+// бюджета и аукциона тендера
+var bidLifecycleViolations = []ruleViolation{
+	{models.ErrTenderNotPublished, http.StatusConflict, response.CodeConflict, "Tender is not published"},
+	deadlinePassed,
+	{models.ErrBidDecided, http.StatusConflict, response.CodeConflict, "Bid already has a decision"},
+	{models.ErrBidCanceled, http.StatusConflict, response.CodeConflict, "Bid is canceled"},
+	{models.ErrBidNotPublished, http.StatusConflict, response.CodeConflict, "Bid is not published"},
+	{models.ErrCurrencyMismatch, http.StatusBadRequest, response.CodeInvalidRequest, "Bid currency does not match tender budget currency"},
+	{models.ErrBudgetExceeded, http.StatusBadRequest, response.CodeInvalidRequest, "Bid price exceeds tender budget"},
+	{models.ErrAuctionStep, http.StatusConflict, response.CodeConflict, "Bid price must beat the best price by the auction step"},
+	{models.ErrAuctionPriceRaise, http.StatusConflict, response.CodeConflict, "Auction bid price can only be lowered"},
+	{models.ErrAuctionRunning, http.StatusConflict, response.CodeConflict, "Auction is still running"},
+	{models.ErrNotBestBid, http.StatusConflict, response.CodeConflict, "Only the best auction bid can be approved"},
+}
+
+// ruleViolationError отправляет ответ, если err - одна из ошибок violations, и возвращает true
+func ruleViolationError(w http.ResponseWriter, handler string, err error, violations []ruleViolation) bool {
+	for _, violation := range violations {
+		if errors.Is(err, violation.err) {
+			log.Printf("%s: %s", handler, violation.message)
+			response.Error(w, violation.status, violation.code, violation.message)
+			return true
+		}
+	}
+	return false
+}
+
+// tenderTransitionError отправляет ответ, если err - ошибка конечного автомата
+// статусов тендера, и возвращает true
+func tenderTransitionError(w http.ResponseWriter, handler string, err error) bool {
+	return ruleViolationError(w, handler, err, tenderTransitionViolations)
+}
+
+// bidLifecycleError отправляет ответ, если err - нарушение правил жизненного
+// цикла предложения, бюджета или аукциона тендера, и возвращает true
+func bidLifecycleError(w http.ResponseWriter, handler string, err error) bool {
+	return ruleViolationError(w, handler, err, bidLifecycleViolations)
+}
@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"avito-project/models"
+	"avito-project/response"
+)
+
+// fakeTenders - репозиторий тендеров в памяти; неиспользуемые методы не реализованы
+type fakeTenders struct {
+	models.TenderRepository
+	tenders map[string]*models.Tender
+	err     error
+}
+
+func (f *fakeTenders) GetByID(ctx context.Context, id string) (*models.Tender, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	tender, ok := f.tenders[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return tender, nil
+}
+
+// IsVisible считает видимыми только опубликованные тендеры
+func (f *fakeTenders) IsVisible(ctx context.Context, id, userID string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	tender, ok := f.tenders[id]
+	return ok && tender.Status == models.TenderStatusPublished, nil
+}
+
+// fakeBids - репозиторий предложений в памяти; неиспользуемые методы не реализованы
+type fakeBids struct {
+	models.BidRepository
+	bids map[string]*models.Bid
+	err  error
+}
+
+func (f *fakeBids) GetByID(ctx context.Context, id string) (*models.Bid, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	bid, ok := f.bids[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return bid, nil
+}
+
+// withIdentity возвращает контекст запроса, как его заполняет AuthMiddleware
+func withIdentity(userID string, responsibilities map[string]string) context.Context {
+	identity := &Identity{
+		User:             &models.Employee{ID: userID},
+		Responsibilities: responsibilities,
+	}
+	return context.WithValue(context.Background(), identityContextKey, identity)
+}
+
+// decodeError разбирает тело ответа с ошибкой
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) response.ErrorResponse {
+	t.Helper()
+	var body response.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	return body
+}
+
+func TestLookupResponsibleTender(t *testing.T) {
+	tenders := &fakeTenders{tenders: map[string]*models.Tender{
+		"tender-1": {ID: "tender-1", OrganizationID: "org-1"},
+	}}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		tenderID string
+		err      error
+		status   int
+		code     string
+	}{
+		{"responsible", withIdentity("user-1", map[string]string{"org-1": "resp-1"}), "tender-1", nil, http.StatusOK, ""},
+		{"not responsible", withIdentity("user-2", map[string]string{"org-2": "resp-2"}), "tender-1", nil, http.StatusForbidden, response.CodePermissionDenied},
+		{"no identity", context.Background(), "tender-1", nil, http.StatusForbidden, response.CodePermissionDenied},
+		{"not found", withIdentity("user-1", map[string]string{"org-1": "resp-1"}), "missing", nil, http.StatusNotFound, response.CodeTenderNotFound},
+		{"db error", withIdentity("user-1", map[string]string{"org-1": "resp-1"}), "tender-1", errors.New("connection refused"), http.StatusInternalServerError, response.CodeInternalError},
+		{"timeout", withIdentity("user-1", map[string]string{"org-1": "resp-1"}), "tender-1", context.DeadlineExceeded, http.StatusGatewayTimeout, response.CodeTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenders.err = tt.err
+			Tenders = tenders
+			rec := httptest.NewRecorder()
+
+			tender, ok := lookupResponsibleTender(tt.ctx, rec, "TestHandler", tt.tenderID)
+			if tt.code == "" {
+				if !ok || tender == nil || tender.ID != tt.tenderID {
+					t.Fatalf("expected tender %s, got %v (ok=%v)", tt.tenderID, tender, ok)
+				}
+				return
+			}
+			if ok {
+				t.Fatalf("expected lookup to fail with %d", tt.status)
+			}
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if body := decodeError(t, rec); body.Code != tt.code {
+				t.Errorf("code = %s, want %s", body.Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestLookupAccessibleBid(t *testing.T) {
+	bids := &fakeBids{bids: map[string]*models.Bid{
+		"user-bid": {ID: "user-bid", AuthorType: models.AuthorTypeUser, AuthorID: "author", AuthorOrganizationIDs: []string{"org-1"}},
+		"org-bid":  {ID: "org-bid", AuthorType: models.AuthorTypeOrganization, AuthorID: "org-2"},
+	}}
+	Bids = bids
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		bidID  string
+		status int
+		code   string
+	}{
+		{"author", withIdentity("author", nil), "user-bid", http.StatusOK, ""},
+		{"colleague of author", withIdentity("colleague", map[string]string{"org-1": "resp-1"}), "user-bid", http.StatusOK, ""},
+		{"responsible for author organization", withIdentity("colleague", map[string]string{"org-2": "resp-2"}), "org-bid", http.StatusOK, ""},
+		{"stranger to user bid", withIdentity("stranger", map[string]string{"org-3": "resp-3"}), "user-bid", http.StatusForbidden, response.CodePermissionDenied},
+		{"stranger to organization bid", withIdentity("stranger", map[string]string{"org-1": "resp-1"}), "org-bid", http.StatusForbidden, response.CodePermissionDenied},
+		{"not found", withIdentity("author", nil), "missing", http.StatusNotFound, response.CodeBidNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			bid, ok := lookupAccessibleBid(tt.ctx, rec, "TestHandler", tt.bidID)
+			if tt.code == "" {
+				if !ok || bid == nil || bid.ID != tt.bidID {
+					t.Fatalf("expected bid %s, got %v (ok=%v)", tt.bidID, bid, ok)
+				}
+				return
+			}
+			if ok {
+				t.Fatalf("expected lookup to fail with %d", tt.status)
+			}
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if body := decodeError(t, rec); body.Code != tt.code {
+				t.Errorf("code = %s, want %s", body.Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestGetBidStatusHandler(t *testing.T) {
+	Bids = &fakeBids{bids: map[string]*models.Bid{
+		"bid-1": {ID: "bid-1", Status: models.BidStatusPublished, AuthorType: models.AuthorTypeUser, AuthorID: "author"},
+	}}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+		want   string
+	}{
+		{"author", withIdentity("author", nil), http.StatusOK, models.BidStatusPublished},
+		{"stranger", withIdentity("stranger", nil), http.StatusForbidden, response.CodePermissionDenied},
+		{"anonymous", context.Background(), http.StatusUnauthorized, response.CodeUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/bids/bid-1/status", nil).WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"bidId": "bid-1"})
+			rec := httptest.NewRecorder()
+
+			GetBidStatusHandler(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status == http.StatusOK {
+				var body map[string]string
+				if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if body["status"] != tt.want {
+					t.Errorf("bid status = %s, want %s", body["status"], tt.want)
+				}
+				return
+			}
+			if body := decodeError(t, rec); body.Code != tt.want {
+				t.Errorf("code = %s, want %s", body.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleViolationError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		handled bool
+		status  int
+		code    string
+	}{
+		{"bid decided", models.ErrBidDecided, true, http.StatusConflict, response.CodeConflict},
+		{"budget exceeded", models.ErrBudgetExceeded, true, http.StatusBadRequest, response.CodeInvalidRequest},
+		{"wrapped deadline", fmt.Errorf("publish bid: %w", models.ErrDeadlinePassed), true, http.StatusConflict, response.CodeConflict},
+		{"not a rule violation", errors.New("connection refused"), false, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			if handled := bidLifecycleError(rec, "TestHandler", tt.err); handled != tt.handled {
+				t.Fatalf("handled = %v, want %v", handled, tt.handled)
+			}
+			if !tt.handled {
+				if rec.Body.Len() != 0 {
+					t.Errorf("unexpected response body: %s", rec.Body.String())
+				}
+				return
+			}
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if body := decodeError(t, rec); body.Code != tt.code {
+				t.Errorf("code = %s, want %s", body.Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestTenderTransitionError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if !tenderTransitionError(rec, "TestHandler", models.ErrReasonRequired) {
+		t.Fatal("expected ErrReasonRequired to be handled")
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	// Ошибки предложений не относятся к переходам тендера
+	rec = httptest.NewRecorder()
+	if tenderTransitionError(rec, "TestHandler", models.ErrBidDecided) {
+		t.Error("expected ErrBidDecided not to be handled as a tender transition error")
+	}
+}
+
+func TestCreateTenderHandlerCreatorMismatch(t *testing.T) {
+	ctx := context.WithValue(context.Background(), identityContextKey, &Identity{
+		User:             &models.Employee{ID: "user-1", Username: "test_user"},
+		Responsibilities: map[string]string{"org-1": "resp-1"},
+	})
+	body := `{"name":"Tender","description":"Build","serviceType":"Delivery","organizationId":"org-1","creatorUsername":"other_user"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/tenders/new", strings.NewReader(body)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	CreateTenderHandler(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if body := decodeError(t, rec); body.Code != response.CodePermissionDenied {
+		t.Errorf("code = %s, want %s", body.Code, response.CodePermissionDenied)
+	}
+}
+
+func TestGetTenderStatusHandler(t *testing.T) {
+	Tenders = &fakeTenders{tenders: map[string]*models.Tender{
+		"published": {ID: "published", Status: models.TenderStatusPublished, OrganizationID: "org-1"},
+		"draft":     {ID: "draft", Status: models.TenderStatusCreated, OrganizationID: "org-1"},
+	}}
+
+	tests := []struct {
+		tenderID string
+		status   int
+	}{
+		{"published", http.StatusOK},
+		{"draft", http.StatusNotFound},
+		{"missing", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tenderID, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/tenders/"+tt.tenderID+"/status", nil).
+				WithContext(withIdentity("stranger", nil))
+			req = mux.SetURLVars(req, map[string]string{"tenderId": tt.tenderID})
+			rec := httptest.NewRecorder()
+
+			GetTenderStatusHandler(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status == http.StatusNotFound {
+				if body := decodeError(t, rec); body.Code != response.CodeTenderNotFound {
+					t.Errorf("code = %s, want %s", body.Code, response.CodeTenderNotFound)
+				}
+			}
+		})
+	}
+}
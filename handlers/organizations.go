@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"avito-project/models"
+	"avito-project/response"
+
+	"github.com/gorilla/mux"
+)
+
+// isValidOrganizationType проверяет значение enum organization_type
+func isValidOrganizationType(organizationType string) bool {
+	switch organizationType {
+	case models.OrganizationTypeIE, models.OrganizationTypeLLC, models.OrganizationTypeJSC:
+		return true
+	}
+	return false
+}
+
+// lookupManagedOrganization находит организацию и проверяет, что пользователь запроса
+// является ее ответственным или администратором. При ошибке отправляет ответ и возвращает false.
+func lookupManagedOrganization(ctx context.Context, w http.ResponseWriter, handler, organizationID string) (*models.Organization, bool) {
+	organization, err := Organizations.GetByID(ctx, organizationID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("%s: Organization not found: %s", handler, organizationID)
+		response.Error(w, http.StatusNotFound, response.CodeOrganizationNotFound, "Organization not found")
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("%s: Failed to retrieve organization: %v", handler, err)
+		response.DBError(w, err, "Failed to retrieve organization")
+		return nil, false
+	}
+
+	identity := identityFromContext(ctx)
+	if _, ok := identity.ResponsibleID(organizationID); !ok && (identity == nil || !identity.User.IsAdmin) {
+		log.Printf("%s: User is not responsible for organization %s", handler, organizationID)
+		response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "User is not responsible for this organization")
+		return nil, false
+	}
+	return organization, true
+}
+
+// GetOrganizationsHandler: Список организаций с пагинацией
+func GetOrganizationsHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+
+	// Пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "GetOrganizationsHandler"); !ok {
+		return
+	}
+
+	// Получение параметров пагинации: limit и offset
+	limit, offset := parsePagination(r)
+
+	log.Printf("GetOrganizationsHandler: Retrieving organizations with limit %d and offset %d", limit, offset)
+
+	organizations, err := Organizations.List(ctx, limit, offset)
+	if err != nil {
+		log.Printf("GetOrganizationsHandler: Failed to retrieve organizations: %v", err)
+		response.DBError(w, err, "Failed to retrieve organizations")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(organizations)
+
+	log.Printf("GetOrganizationsHandler: Successfully retrieved organizations in %v", time.Since(start))
+}
+
+// CreateOrganizationHandler: Создание организации, создатель становится ответственным
+func CreateOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	log.Println("CreateOrganizationHandler: Creating a new organization")
+
+	var input struct {
+		Name        string  `json:"name"`
+		Description *string `json:"description"`
+		Type        string  `json:"type"`
+	}
+
+	// Декодирование JSON тела запроса
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("CreateOrganizationHandler: Invalid input: %v", err)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
+		return
+	}
+
+	if !isValidOrganizationType(input.Type) {
+		log.Printf("CreateOrganizationHandler: Invalid organization type: %s", input.Type)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid organization type")
+		return
+	}
+
+	// Создатель организации определяется по токену
+	creator, ok := currentUser(ctx, w, "CreateOrganizationHandler")
+	if !ok {
+		return
+	}
+
+	organization := models.Organization{
+		Name:        input.Name,
+		Description: input.Description,
+		Type:        &input.Type,
+	}
+	err = Organizations.Create(ctx, &organization, creator.ID)
+	if err != nil {
+		log.Printf("CreateOrganizationHandler: Failed to create organization: %v", err)
+		response.DBError(w, err, "Failed to create organization")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(organization)
+
+	log.Printf("CreateOrganizationHandler: Organization created successfully in %v", time.Since(start))
+}
+
+// GetOrganizationHandler: Получение организации по ID
+func GetOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	organizationID := mux.Vars(r)["organizationId"]
+
+	log.Printf("GetOrganizationHandler: Retrieving organization %s", organizationID)
+
+	// Пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "GetOrganizationHandler"); !ok {
+		return
+	}
+
+	organization, err := Organizations.GetByID(ctx, organizationID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("GetOrganizationHandler: Organization not found: %s", organizationID)
+		response.Error(w, http.StatusNotFound, response.CodeOrganizationNotFound, "Organization not found")
+		return
+	}
+	if err != nil {
+		log.Printf("GetOrganizationHandler: Failed to retrieve organization: %v", err)
+		response.DBError(w, err, "Failed to retrieve organization")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(organization)
+
+	log.Printf("GetOrganizationHandler: Successfully retrieved organization in %v", time.Since(start))
+}
+
+// EditOrganizationHandler: Изменение организации ответственным или администратором
+func EditOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	organizationID := mux.Vars(r)["organizationId"]
+
+	log.Printf("EditOrganizationHandler: Editing organization %s", organizationID)
+
+	// Пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "EditOrganizationHandler"); !ok {
+		return
+	}
+
+	// Проверка существования организации и прав пользователя
+	if _, ok := lookupManagedOrganization(ctx, w, "EditOrganizationHandler", organizationID); !ok {
+		return
+	}
+
+	// Декодирование запроса
+	var update models.OrganizationUpdate
+	err := json.NewDecoder(r.Body).Decode(&update)
+	if err != nil {
+		log.Printf("EditOrganizationHandler: Invalid input: %v", err)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
+		return
+	}
+
+	if update.Name == nil && update.Description == nil && update.Type == nil {
+		log.Println("EditOrganizationHandler: No fields to update")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "No fields to update")
+		return
+	}
+	if update.Type != nil && !isValidOrganizationType(*update.Type) {
+		log.Printf("EditOrganizationHandler: Invalid organization type: %s", *update.Type)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid organization type")
+		return
+	}
+
+	organization, err := Organizations.Update(ctx, organizationID, update)
+	if err != nil {
+		log.Printf("EditOrganizationHandler: Failed to update organization: %v", err)
+		response.DBError(w, err, "Failed to update organization")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(organization)
+
+	log.Printf("EditOrganizationHandler: Organization updated successfully in %v", time.Since(start))
+}
+
+// DeleteOrganizationHandler: Удаление организации без тендеров
+func DeleteOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	organizationID := mux.Vars(r)["organizationId"]
+
+	log.Printf("DeleteOrganizationHandler: Deleting organization %s", organizationID)
+
+	// Пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "DeleteOrganizationHandler"); !ok {
+		return
+	}
+
+	// Проверка существования организации и прав пользователя
+	if _, ok := lookupManagedOrganization(ctx, w, "DeleteOrganizationHandler", organizationID); !ok {
+		return
+	}
+
+	err := Organizations.Delete(ctx, organizationID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("DeleteOrganizationHandler: Organization not found: %s", organizationID)
+		response.Error(w, http.StatusNotFound, response.CodeOrganizationNotFound, "Organization not found")
+		return
+	}
+	if errors.Is(err, models.ErrConflict) {
+		log.Printf("DeleteOrganizationHandler: Organization %s has tenders", organizationID)
+		response.Error(w, http.StatusConflict, response.CodeConflict, "Organization has tenders")
+		return
+	}
+	if err != nil {
+		log.Printf("DeleteOrganizationHandler: Failed to delete organization: %v", err)
+		response.DBError(w, err, "Failed to delete organization")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+
+	log.Printf("DeleteOrganizationHandler: Organization deleted successfully in %v", time.Since(start))
+}
+
+// GetOrganizationResponsiblesHandler: Список ответственных за организацию
+func GetOrganizationResponsiblesHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	organizationID := mux.Vars(r)["organizationId"]
+
+	log.Printf("GetOrganizationResponsiblesHandler: Retrieving responsibles of organization %s", organizationID)
+
+	// Пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "GetOrganizationResponsiblesHandler"); !ok {
+		return
+	}
+
+	// Проверка существования организации
+	_, err := Organizations.GetByID(ctx, organizationID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("GetOrganizationResponsiblesHandler: Organization not found: %s", organizationID)
+		response.Error(w, http.StatusNotFound, response.CodeOrganizationNotFound, "Organization not found")
+		return
+	}
+	if err != nil {
+		log.Printf("GetOrganizationResponsiblesHandler: Failed to retrieve organization: %v", err)
+		response.DBError(w, err, "Failed to retrieve organization")
+		return
+	}
+
+	responsibles, err := Organizations.ListResponsibles(ctx, organizationID)
+	if err != nil {
+		log.Printf("GetOrganizationResponsiblesHandler: Failed to retrieve responsibles: %v", err)
+		response.DBError(w, err, "Failed to retrieve responsibles")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(responsibles)
+
+	log.Printf("GetOrganizationResponsiblesHandler: Successfully retrieved responsibles in %v", time.Since(start))
+}
+
+// AddOrganizationResponsibleHandler: Назначение сотрудника ответственным за организацию
+func AddOrganizationResponsibleHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	organizationID := mux.Vars(r)["organizationId"]
+
+	log.Printf("AddOrganizationResponsibleHandler: Adding responsible to organization %s", organizationID)
+
+	var input struct {
+		Username string `json:"username"`
+	}
+
+	// Декодирование JSON тела запроса
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("AddOrganizationResponsibleHandler: Invalid input: %v", err)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
+		return
+	}
+
+	// Пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "AddOrganizationResponsibleHandler"); !ok {
+		return
+	}
+
+	// Проверка существования организации и прав пользователя
+	if _, ok := lookupManagedOrganization(ctx, w, "AddOrganizationResponsibleHandler", organizationID); !ok {
+		return
+	}
+
+	// Проверка существования назначаемого сотрудника
+	employee, err := Employees.GetByUsername(ctx, input.Username)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("AddOrganizationResponsibleHandler: User not found: %s", input.Username)
+		response.Error(w, http.StatusNotFound, response.CodeUserNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		log.Printf("AddOrganizationResponsibleHandler: Failed to retrieve user: %v", err)
+		response.DBError(w, err, "Failed to retrieve user")
+		return
+	}
+
+	err = Organizations.AddResponsible(ctx, organizationID, employee.ID)
+	if err != nil {
+		log.Printf("AddOrganizationResponsibleHandler: Failed to add responsible: %v", err)
+		response.DBError(w, err, "Failed to add responsible")
+		return
+	}
+
+	responsibles, err := Organizations.ListResponsibles(ctx, organizationID)
+	if err != nil {
+		log.Printf("AddOrganizationResponsibleHandler: Failed to retrieve responsibles: %v", err)
+		response.DBError(w, err, "Failed to retrieve responsibles")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(responsibles)
+
+	log.Printf("AddOrganizationResponsibleHandler: User %s added to organization %s in %v", input.Username, organizationID, time.Since(start))
+}
+
+// RemoveOrganizationResponsibleHandler: Снятие сотрудника с ответственных за организацию
+func RemoveOrganizationResponsibleHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	organizationID := vars["organizationId"]
+	userID := vars["userId"]
+
+	log.Printf("RemoveOrganizationResponsibleHandler: Removing user %s from organization %s", userID, organizationID)
+
+	// Пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "RemoveOrganizationResponsibleHandler"); !ok {
+		return
+	}
+
+	// Проверка существования организации и прав пользователя
+	if _, ok := lookupManagedOrganization(ctx, w, "RemoveOrganizationResponsibleHandler", organizationID); !ok {
+		return
+	}
+
+	err := Organizations.RemoveResponsible(ctx, organizationID, userID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("RemoveOrganizationResponsibleHandler: User %s is not responsible for organization %s", userID, organizationID)
+		response.Error(w, http.StatusNotFound, response.CodeUserNotFound, "User is not responsible for this organization")
+		return
+	}
+	if err != nil {
+		log.Printf("RemoveOrganizationResponsibleHandler: Failed to remove responsible: %v", err)
+		response.DBError(w, err, "Failed to remove responsible")
+		return
+	}
+
+	responsibles, err := Organizations.ListResponsibles(ctx, organizationID)
+	if err != nil {
+		log.Printf("RemoveOrganizationResponsibleHandler: Failed to retrieve responsibles: %v", err)
+		response.DBError(w, err, "Failed to retrieve responsibles")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(responsibles)
+
+	log.Printf("RemoveOrganizationResponsibleHandler: User %s removed from organization %s in %v", userID, organizationID, time.Since(start))
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"avito-project/models"
+)
+
+// defaultLimit - limit по умолчанию, как в параметре paginationLimit задание/openapi.yml
+const defaultLimit = 5
+
+// parsePagination получает параметры пагинации limit и offset из query.
+// Некорректные значения заменяются значениями по умолчанию.
+func parsePagination(r *http.Request) (limit, offset int) {
+	limitParam := r.URL.Query().Get("limit")
+	offsetParam := r.URL.Query().Get("offset")
+
+	// Установка значений по умолчанию для limit и offset
+	limit = defaultLimit
+	offset = 0
+
+	// Если переданы параметры пагинации, пытаемся их конвертировать
+	if limitParam != "" {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+	if offsetParam != "" {
+		parsedOffset, err := strconv.Atoi(offsetParam)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+	return limit, offset
+}
+
+// maxAmount - верхняя граница денежных сумм в копейках, соответствует NUMERIC(15, 2)
+const maxAmount models.Amount = 1e15
+
+// checkAmount проверяет денежную сумму: она должна быть положительной и меньше 10^13.
+// Точность до копейки обеспечивает разбор models.Amount. Возвращает описание ошибки или пустую строку.
+func checkAmount(name string, amount models.Amount) string {
+	if amount <= 0 || amount >= maxAmount {
+		return name + " must be positive and less than 10^13"
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"avito-project/models"
+	"avito-project/response"
+
+	"github.com/gorilla/mux"
+)
+
+// FieldDiff - изменение одного поля между двумя версиями тендера
+type FieldDiff struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Changed bool   `json:"changed"`
+}
+
+// GetTenderVersionsHandler: Список всех версий тендера
+func GetTenderVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	tenderId := vars["tenderId"]
+
+	log.Printf("GetTenderVersionsHandler: Retrieving versions for tender %s", tenderId)
+
+	if _, ok := currentUser(ctx, w, "GetTenderVersionsHandler"); !ok {
+		return
+	}
+	if _, ok := lookupResponsibleTender(ctx, w, "GetTenderVersionsHandler", tenderId); !ok {
+		return
+	}
+
+	versions, err := Tenders.Versions(ctx, tenderId)
+	if err != nil {
+		log.Printf("GetTenderVersionsHandler: Failed to retrieve versions: %v", err)
+		response.DBError(w, err, "Failed to retrieve versions")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(versions)
+
+	log.Printf("GetTenderVersionsHandler: Successfully retrieved versions in %v", time.Since(start))
+}
+
+// DiffTenderVersionsHandler: Сравнение двух версий тендера по полям
+func DiffTenderVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	tenderId := vars["tenderId"]
+
+	log.Printf("DiffTenderVersionsHandler: Comparing versions %s and %s of tender %s", vars["a"], vars["b"], tenderId)
+
+	fromVersion, errA := strconv.Atoi(vars["a"])
+	toVersion, errB := strconv.Atoi(vars["b"])
+	if errA != nil || errB != nil || fromVersion < 1 || toVersion < 1 {
+		log.Printf("DiffTenderVersionsHandler: Invalid version format: %s, %s", vars["a"], vars["b"])
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid version format")
+		return
+	}
+
+	if _, ok := currentUser(ctx, w, "DiffTenderVersionsHandler"); !ok {
+		return
+	}
+	if _, ok := lookupResponsibleTender(ctx, w, "DiffTenderVersionsHandler", tenderId); !ok {
+		return
+	}
+
+	versions, err := Tenders.Versions(ctx, tenderId)
+	if err != nil {
+		log.Printf("DiffTenderVersionsHandler: Failed to retrieve versions: %v", err)
+		response.DBError(w, err, "Failed to retrieve versions")
+		return
+	}
+
+	var from, to *models.TenderVersion
+	for i := range versions {
+		if versions[i].Version == fromVersion {
+			from = &versions[i]
+		}
+		if versions[i].Version == toVersion {
+			to = &versions[i]
+		}
+	}
+	if from == nil || to == nil {
+		log.Printf("DiffTenderVersionsHandler: Version not found for tender %s", tenderId)
+		response.Error(w, http.StatusNotFound, response.CodeVersionNotFound, "Version not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"tenderId":    tenderId,
+		"fromVersion": fromVersion,
+		"toVersion":   toVersion,
+		"diff": map[string]FieldDiff{
+			"name":        {From: from.Name, To: to.Name, Changed: from.Name != to.Name},
+			"description": {From: from.Description, To: to.Description, Changed: from.Description != to.Description},
+			"serviceType": {From: from.ServiceType, To: to.ServiceType, Changed: from.ServiceType != to.ServiceType},
+		},
+	})
+
+	log.Printf("DiffTenderVersionsHandler: Successfully compared versions in %v", time.Since(start))
+}
@@ -1,18 +1,18 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
-	"avito-project/db"
+	"avito-project/models"
+	"avito-project/response"
 
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v4"
 )
 
 func PingHandler(w http.ResponseWriter, r *http.Request) {
@@ -21,64 +21,31 @@ func PingHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("ok"))
 }
 
-// Tender represents a tender record
-type Tender struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	ServiceType string    `json:"serviceType"`
-	Status      string    `json:"status"`
-	Version     int       `json:"version"`
-	CreatedAt   time.Time `json:"createdAt"`
-}
-
 func GetTendersHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
+	ctx := r.Context()
 	log.Println("GetTendersHandler: Retrieving list of tenders")
 
-	// Получение фильтра из query-параметров
-	serviceTypeFilter := r.URL.Query().Get("service_type")
-
-	conn := db.GetConnection()
-	var rows pgx.Rows
-	var err error
+	// Получение фильтров и пагинации из query-параметров
+	limit, offset := parsePagination(r)
+	filter := models.TenderFilter{
+		ServiceTypes: r.URL.Query()["service_type"],
+		Limit:        limit,
+		Offset:       offset,
+	}
 
-	// Проверяем, передан ли фильтр по service_type
-	if serviceTypeFilter != "" {
-		// Если фильтр передан, добавляем условие WHERE в запрос
-		rows, err = conn.Query(context.Background(),
-			"SELECT id, name, description, service_type, status FROM tender WHERE service_type = $1", serviceTypeFilter)
-	} else {
-		// Если фильтр не передан, возвращаем все записи
-		rows, err = conn.Query(context.Background(),
-			"SELECT id, name, description, service_type, status FROM tender")
+	// Анонимный пользователь видит только опубликованные тендеры,
+	// ответственный - также тендеры своей организации в любом статусе
+	if user := userFromContext(ctx); user != nil {
+		filter.ViewerID = user.ID
 	}
 
+	tenders, err := Tenders.List(ctx, filter)
 	if err != nil {
 		log.Printf("GetTendersHandler: Failed to retrieve tenders: %v", err)
-		http.Error(w, "Failed to retrieve tenders", http.StatusInternalServerError)
+		response.DBError(w, err, "Failed to retrieve tenders")
 		return
 	}
-	defer rows.Close()
-
-	var tenders []map[string]interface{}
-
-	for rows.Next() {
-		var id, name, description, serviceType, status string
-		err = rows.Scan(&id, &name, &description, &serviceType, &status)
-		if err != nil {
-			log.Printf("GetTendersHandler: Failed to scan tenders: %v", err)
-			http.Error(w, "Failed to scan tenders", http.StatusInternalServerError)
-			return
-		}
-		tenders = append(tenders, map[string]interface{}{
-			"id":          id,
-			"name":        name,
-			"description": description,
-			"serviceType": serviceType,
-			"status":      status,
-		})
-	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -89,154 +56,181 @@ func GetTendersHandler(w http.ResponseWriter, r *http.Request) {
 
 func CreateTenderHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
+	ctx := r.Context()
 	log.Println("CreateTenderHandler: Creating a new tender")
 
-	var tender struct {
-		Name            string `json:"name"`
-		Description     string `json:"description"`
-		ServiceType     string `json:"serviceType"`
-		OrganizationId  string `json:"organizationId"`
+	var input struct {
+		Name           string `json:"name"`
+		Description    string `json:"description"`
+		ServiceType    string `json:"serviceType"`
+		OrganizationId string `json:"organizationId"`
+		// Срок подачи предложений и время автоматической публикации (необязательные)
+		SubmissionDeadline *time.Time `json:"submissionDeadline"`
+		PublishAt          *time.Time `json:"publishAt"`
+		// Максимальный бюджет и его валюта (необязательные, задаются вместе)
+		Budget   *models.Amount `json:"budget"`
+		Currency *string        `json:"currency"`
+		// Тип тендера и параметры обратного аукциона
+		Type                    string         `json:"type"`
+		AuctionStep             *models.Amount `json:"auctionStep"`
+		AuctionExtensionMinutes *int           `json:"auctionExtensionMinutes"`
+		// Автор из спецификации; если передан, должен совпадать с пользователем токена
 		CreatorUsername string `json:"creatorUsername"`
 	}
 
 	// Декодирование JSON тела запроса
-	err := json.NewDecoder(r.Body).Decode(&tender)
+	err := json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
 		log.Printf("CreateTenderHandler: Invalid input: %v", err)
-		http.Error(w, "Invalid input", http.StatusBadRequest)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
 		return
 	}
 
-	conn := db.GetConnection()
-
-	// Проверка существования пользователя
-	var creatorID string
-	err = conn.QueryRow(context.Background(), "SELECT id FROM employee WHERE username = $1", tender.CreatorUsername).Scan(&creatorID)
-	if err != nil {
-		log.Printf("CreateTenderHandler: User not found: %v", err)
-		http.Error(w, "User not found", http.StatusUnauthorized)
+	// Пользователь определяется по токену
+	creator, ok := currentUser(ctx, w, "CreateTenderHandler")
+	if !ok {
+		return
+	}
+	if input.CreatorUsername != "" && input.CreatorUsername != creator.Username {
+		log.Printf("CreateTenderHandler: Creator username %s does not match user %s", input.CreatorUsername, creator.Username)
+		response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "Creator username does not match the authenticated user")
 		return
 	}
 
 	// Проверка, является ли пользователь ответственным за организацию
-	var responsibleID string
-	err = conn.QueryRow(context.Background(), `
-		SELECT id FROM organization_responsible
-		WHERE organization_id = $1 AND user_id = $2`, tender.OrganizationId, creatorID).Scan(&responsibleID)
-	if err != nil {
-		log.Printf("CreateTenderHandler: User is not responsible for this organization: %v", err)
-		http.Error(w, "User is not responsible for this organization", http.StatusForbidden)
+	responsibleID, ok := identityFromContext(ctx).ResponsibleID(input.OrganizationId)
+	if !ok {
+		log.Printf("CreateTenderHandler: User is not responsible for organization %s", input.OrganizationId)
+		response.Error(w, http.StatusForbidden, response.CodePermissionDenied, "User is not responsible for this organization")
 		return
 	}
 
+	if reason := checkTenderSchedule(input.SubmissionDeadline, input.PublishAt); reason != "" {
+		log.Printf("CreateTenderHandler: Invalid schedule: %s", reason)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, reason)
+		return
+	}
+
+	if reason := checkTenderBudget(input.Budget, input.Currency); reason != "" {
+		log.Printf("CreateTenderHandler: Invalid budget: %s", reason)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, reason)
+		return
+	}
+
+	if input.Type == "" {
+		input.Type = models.TenderTypeStandard
+	}
+	if reason := checkTenderAuction(input.Type, input.SubmissionDeadline, input.Budget, input.AuctionStep, input.AuctionExtensionMinutes); reason != "" {
+		log.Printf("CreateTenderHandler: Invalid auction parameters: %s", reason)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, reason)
+		return
+	}
+	if input.Type == models.TenderTypeAuction && input.AuctionExtensionMinutes == nil {
+		extension := defaultAuctionExtension
+		input.AuctionExtensionMinutes = &extension
+	}
+
 	// Создание тендера
-	query := `INSERT INTO tender (name, description, service_type, organization_id, creator_id, responsible_id, status, version, created_at)
-			  VALUES ($1, $2, $3, $4, $5, $6, 'CREATED', 1, CURRENT_TIMESTAMP) RETURNING id, created_at`
-	var tenderID string
-	var createdAt time.Time
-	err = conn.QueryRow(context.Background(), query,
-		tender.Name, tender.Description, tender.ServiceType, tender.OrganizationId, creatorID, responsibleID).
-		Scan(&tenderID, &createdAt)
+	tender := models.Tender{
+		Name:                    input.Name,
+		Description:             input.Description,
+		ServiceType:             input.ServiceType,
+		OrganizationID:          input.OrganizationId,
+		CreatorID:               creator.ID,
+		SubmissionDeadline:      input.SubmissionDeadline,
+		PublishAt:               input.PublishAt,
+		Budget:                  input.Budget,
+		Currency:                input.Currency,
+		Type:                    input.Type,
+		AuctionStep:             input.AuctionStep,
+		AuctionExtensionMinutes: input.AuctionExtensionMinutes,
+	}
+	err = Tenders.Create(ctx, &tender, responsibleID)
 	if err != nil {
 		log.Printf("CreateTenderHandler: Failed to create tender: %v", err)
-		http.Error(w, "Failed to create tender", http.StatusInternalServerError)
+		response.DBError(w, err, "Failed to create tender")
 		return
 	}
 
 	// Ответ с данными созданного тендера
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"id":          tenderID,
-		"name":        tender.Name,
-		"description": tender.Description,
-		"serviceType": tender.ServiceType,
-		"status":      "CREATED",
-		"version":     1, // Начальная версия тендера
-		"createdAt":   createdAt.Format(time.RFC3339),
-	})
+	json.NewEncoder(w).Encode(tender)
 
 	log.Printf("CreateTenderHandler: Tender created successfully in %v", time.Since(start))
 }
 
 func GetMyTendersHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		log.Println("GetMyTendersHandler: Username is required")
-		http.Error(w, "Username is required", http.StatusBadRequest)
+	ctx := r.Context()
+
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "GetMyTendersHandler")
+	if !ok {
 		return
 	}
 
-	log.Printf("GetMyTendersHandler: Retrieving tenders for user %s", username)
-
-	conn := db.GetConnection()
+	// Получение фильтров и пагинации из query-параметров
+	limit, offset := parsePagination(r)
 
-	var userID string
-	err := conn.QueryRow(context.Background(), "SELECT id FROM employee WHERE username = $1", username).Scan(&userID)
-	if err != nil {
-		log.Printf("GetMyTendersHandler: User not found: %v", err)
-		http.Error(w, "User not found", http.StatusBadRequest)
-		return
-	}
+	log.Printf("GetMyTendersHandler: Retrieving tenders for user %s with limit %d and offset %d", user.Username, limit, offset)
 
-	rows, err := conn.Query(context.Background(), "SELECT id, name, description FROM tender WHERE creator_id = $1", userID)
+	tenders, err := Tenders.List(ctx, models.TenderFilter{
+		CreatorID:    user.ID,
+		ServiceTypes: r.URL.Query()["service_type"],
+		Limit:        limit,
+		Offset:       offset,
+	})
 	if err != nil {
 		log.Printf("GetMyTendersHandler: Failed to retrieve tenders: %v", err)
-		http.Error(w, "Failed to retrieve tenders", http.StatusInternalServerError)
+		response.DBError(w, err, "Failed to retrieve tenders")
 		return
 	}
-	defer rows.Close()
-
-	var tenders []map[string]interface{}
-
-	for rows.Next() {
-		var id, name, description string
-		err = rows.Scan(&id, &name, &description)
-		if err != nil {
-			log.Printf("GetMyTendersHandler: Failed to scan tenders: %v", err)
-			http.Error(w, "Failed to scan tenders", http.StatusInternalServerError)
-			return
-		}
-		tenders = append(tenders, map[string]interface{}{
-			"id":          id,
-			"name":        name,
-			"description": description,
-		})
-	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(tenders)
 
-	log.Printf("GetMyTendersHandler: Successfully retrieved tenders for user %s in %v", username, time.Since(start))
+	log.Printf("GetMyTendersHandler: Successfully retrieved tenders for user %s in %v", user.Username, time.Since(start))
 }
 
 func GetTenderStatusHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
+	ctx := r.Context()
 	vars := mux.Vars(r)
 	tenderId := vars["tenderId"]
-	username := r.URL.Query().Get("username")
 
 	log.Printf("GetTenderStatusHandler: Getting status for tender %s", tenderId)
 
-	conn := db.GetConnection()
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "GetTenderStatusHandler")
+	if !ok {
+		return
+	}
 
-	// Проверка существования пользователя
-	var userID string
-	err := conn.QueryRow(context.Background(), "SELECT id FROM employee WHERE username = $1", username).Scan(&userID)
+	// Статус виден тем, кому виден тендер: черновики чужих организаций не раскрываются
+	visible, err := Tenders.IsVisible(ctx, tenderId, user.ID)
 	if err != nil {
-		log.Printf("GetTenderStatusHandler: User not found: %v", err)
-		http.Error(w, "User not found", http.StatusUnauthorized)
+		log.Printf("GetTenderStatusHandler: Failed to check tender existence: %v", err)
+		response.DBError(w, err, "Failed to check tender existence")
+		return
+	}
+	if !visible {
+		log.Printf("GetTenderStatusHandler: Tender not found or not published: %s", tenderId)
+		response.Error(w, http.StatusNotFound, response.CodeTenderNotFound, "Tender not found")
 		return
 	}
 
 	// Получение статуса тендера
-	var status string
-	err = conn.QueryRow(context.Background(), "SELECT status FROM tender WHERE id = $1", tenderId).Scan(&status)
+	tender, err := Tenders.GetByID(ctx, tenderId)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("GetTenderStatusHandler: Tender not found: %s", tenderId)
+		response.Error(w, http.StatusNotFound, response.CodeTenderNotFound, "Tender not found")
+		return
+	}
 	if err != nil {
-		log.Printf("GetTenderStatusHandler: Tender not found: %v", err)
-		http.Error(w, "Tender not found", http.StatusNotFound)
+		log.Printf("GetTenderStatusHandler: Failed to retrieve tender: %v", err)
+		response.DBError(w, err, "Failed to retrieve tender")
 		return
 	}
 
@@ -244,7 +238,7 @@ func GetTenderStatusHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{
-		"status": status,
+		"status": tender.Status,
 	})
 
 	log.Printf("GetTenderStatusHandler: Successfully retrieved status in %v", time.Since(start))
@@ -253,150 +247,192 @@ func GetTenderStatusHandler(w http.ResponseWriter, r *http.Request) {
 // UpdateTenderStatusHandler: Изменить статус тендера по ID
 func UpdateTenderStatusHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
+	ctx := r.Context()
 	vars := mux.Vars(r)
 	tenderId := vars["tenderId"]
 	status := strings.ToUpper(r.URL.Query().Get("status"))
-	username := r.URL.Query().Get("username")
+	reason := strings.TrimSpace(r.URL.Query().Get("reason"))
 
 	log.Printf("UpdateTenderStatusHandler: Updating status for tender %s", tenderId)
 
-	conn := db.GetConnection()
-
-	// Проверка существования пользователя
-	var userID string
-	err := conn.QueryRow(context.Background(), "SELECT id FROM employee WHERE username = $1", username).Scan(&userID)
-	if err != nil {
-		log.Printf("UpdateTenderStatusHandler: User not found: %v", err)
-		http.Error(w, "User not found", http.StatusUnauthorized)
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "UpdateTenderStatusHandler")
+	if !ok {
 		return
 	}
 
 	// Проверка прав пользователя
-	var responsibleID string
-	err = conn.QueryRow(context.Background(), `
-		SELECT id FROM organization_responsible
-		WHERE organization_id = (SELECT organization_id FROM tender WHERE id = $1)
-		AND user_id = $2`, tenderId, userID).Scan(&responsibleID)
-	if err != nil {
-		log.Printf("UpdateTenderStatusHandler: User is not responsible for this tender: %v", err)
-		http.Error(w, "User is not responsible for this tender", http.StatusForbidden)
+	if _, ok = lookupResponsibleTender(ctx, w, "UpdateTenderStatusHandler", tenderId); !ok {
 		return
 	}
 
 	// Проверка допустимого статуса
-	if status != "CREATED" && status != "PUBLISHED" && status != "CLOSED" {
+	if status != models.TenderStatusCreated && status != models.TenderStatusPublished && status != models.TenderStatusClosed {
 		log.Printf("UpdateTenderStatusHandler: Invalid status value: %s", status)
-		http.Error(w, "Invalid status value", http.StatusBadRequest)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid status value")
 		return
 	}
 
-	// Обновление статуса тендера
-	_, err = conn.Exec(context.Background(), "UPDATE tender SET status = $1 WHERE id = $2", status, tenderId)
+	// Переход в новый статус по правилам конечного автомата
+	tender, err := Tenders.ChangeStatus(ctx, tenderId, status, reason, user.ID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("UpdateTenderStatusHandler: Tender not found: %s", tenderId)
+		response.Error(w, http.StatusNotFound, response.CodeTenderNotFound, "Tender not found")
+		return
+	}
+	if tenderTransitionError(w, "UpdateTenderStatusHandler", err) {
+		return
+	}
 	if err != nil {
 		log.Printf("UpdateTenderStatusHandler: Failed to update status: %v", err)
-		http.Error(w, "Failed to update status", http.StatusInternalServerError)
+		response.DBError(w, err, "Failed to update status")
 		return
 	}
 
 	// Успешный ответ
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{
-		"status": status,
-	})
+	json.NewEncoder(w).Encode(tender)
 
 	log.Printf("UpdateTenderStatusHandler: Successfully updated status in %v", time.Since(start))
 }
 
+// GetTenderStatusHistoryHandler: История переходов тендера между статусами
+func GetTenderStatusHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx := r.Context()
+	tenderId := mux.Vars(r)["tenderId"]
+
+	log.Printf("GetTenderStatusHistoryHandler: Retrieving status history for tender %s", tenderId)
+
+	// Пользователь определяется по токену
+	if _, ok := currentUser(ctx, w, "GetTenderStatusHistoryHandler"); !ok {
+		return
+	}
+
+	// Проверка прав пользователя
+	if _, ok := lookupResponsibleTender(ctx, w, "GetTenderStatusHistoryHandler", tenderId); !ok {
+		return
+	}
+
+	history, err := Tenders.StatusHistory(ctx, tenderId)
+	if err != nil {
+		log.Printf("GetTenderStatusHistoryHandler: Failed to retrieve status history: %v", err)
+		response.DBError(w, err, "Failed to retrieve status history")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(history)
+
+	log.Printf("GetTenderStatusHistoryHandler: Successfully retrieved status history in %v", time.Since(start))
+}
+
 func EditTenderHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
+	ctx := r.Context()
 	vars := mux.Vars(r)
 	tenderId := vars["tenderId"]
-	username := r.URL.Query().Get("username")
 
 	log.Printf("EditTenderHandler: Editing tender %s", tenderId)
 
-	// Проверка существования пользователя
-	conn := db.GetConnection()
-	var userID string
-	err := conn.QueryRow(context.Background(), "SELECT id FROM employee WHERE username = $1", username).Scan(&userID)
-	if err != nil {
-		log.Printf("EditTenderHandler: User not found: %v", err)
-		http.Error(w, "User not found", http.StatusUnauthorized)
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "EditTenderHandler")
+	if !ok {
 		return
 	}
 
 	// Проверка прав пользователя на редактирование тендера
-	var responsibleID string
-	err = conn.QueryRow(context.Background(), `
-		SELECT id FROM organization_responsible
-		WHERE organization_id = (SELECT organization_id FROM tender WHERE id = $1)
-		AND user_id = $2`, tenderId, userID).Scan(&responsibleID)
-	if err != nil {
-		log.Printf("EditTenderHandler: User is not responsible for this tender: %v", err)
-		http.Error(w, "User is not responsible for this tender", http.StatusForbidden)
+	current, ok := lookupResponsibleTender(ctx, w, "EditTenderHandler", tenderId)
+	if !ok {
 		return
 	}
 
 	// Декодирование запроса
-	var updates map[string]interface{}
-	err = json.NewDecoder(r.Body).Decode(&updates)
+	var update models.TenderUpdate
+	err := json.NewDecoder(r.Body).Decode(&update)
 	if err != nil {
 		log.Printf("EditTenderHandler: Invalid input: %v", err)
-		http.Error(w, "Invalid input", http.StatusBadRequest)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
 		return
 	}
 
-	// Построение запроса на обновление
-	var fields []string
-	var values []interface{}
-	idx := 1
-
-	if name, ok := updates["name"].(string); ok {
-		fields = append(fields, "name = $"+strconv.Itoa(idx))
-		values = append(values, name)
-		idx++
+	if update.Name == nil && update.Description == nil && update.ServiceType == nil &&
+		update.SubmissionDeadline == nil && update.PublishAt == nil && update.Budget == nil && update.Currency == nil &&
+		update.AuctionStep == nil && update.AuctionExtensionMinutes == nil {
+		log.Println("EditTenderHandler: No fields to update")
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "No fields to update")
+		return
 	}
 
-	if description, ok := updates["description"].(string); ok {
-		fields = append(fields, "description = $"+strconv.Itoa(idx))
-		values = append(values, description)
-		idx++
+	// Новое расписание проверяется вместе с неизмененными полями тендера
+	if update.SubmissionDeadline != nil || update.PublishAt != nil {
+		deadline, publishAt := current.SubmissionDeadline, current.PublishAt
+		if update.SubmissionDeadline != nil {
+			deadline = update.SubmissionDeadline
+		}
+		if update.PublishAt != nil {
+			publishAt = update.PublishAt
+		}
+		if reason := checkTenderSchedule(deadline, publishAt); reason != "" {
+			log.Printf("EditTenderHandler: Invalid schedule: %s", reason)
+			response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, reason)
+			return
+		}
 	}
 
-	if serviceType, ok := updates["serviceType"].(string); ok {
-		fields = append(fields, "service_type = $"+strconv.Itoa(idx))
-		values = append(values, serviceType)
-		idx++
+	// Бюджет и валюта проверяются так же, вместе с текущими значениями
+	if update.Budget != nil || update.Currency != nil {
+		budget, currency := current.Budget, current.Currency
+		if update.Budget != nil {
+			budget = update.Budget
+		}
+		if update.Currency != nil {
+			currency = update.Currency
+		}
+		if reason := checkTenderBudget(budget, currency); reason != "" {
+			log.Printf("EditTenderHandler: Invalid budget: %s", reason)
+			response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, reason)
+			return
+		}
 	}
 
-	if len(fields) == 0 {
-		log.Println("EditTenderHandler: No fields to update")
-		http.Error(w, "No fields to update", http.StatusBadRequest)
+	// Параметры аукциона проверяются по итоговому состоянию тендера
+	merged := *current
+	if update.SubmissionDeadline != nil {
+		merged.SubmissionDeadline = update.SubmissionDeadline
+	}
+	if update.Budget != nil {
+		merged.Budget = update.Budget
+	}
+	if update.AuctionStep != nil {
+		merged.AuctionStep = update.AuctionStep
+	}
+	if update.AuctionExtensionMinutes != nil {
+		merged.AuctionExtensionMinutes = update.AuctionExtensionMinutes
+	}
+	if reason := checkTenderAuction(merged.Type, merged.SubmissionDeadline, merged.Budget, merged.AuctionStep, merged.AuctionExtensionMinutes); reason != "" {
+		log.Printf("EditTenderHandler: Invalid auction parameters: %s", reason)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, reason)
 		return
 	}
 
-	// Инкремент версии и добавление в запрос
-	fields = append(fields, "version = version + 1, updated_at = CURRENT_TIMESTAMP")
-	query := "UPDATE tender SET " + strings.Join(fields, ", ") + " WHERE id = $" + strconv.Itoa(idx)
-	values = append(values, tenderId)
-
-	// Выполнение запроса
-	_, err = conn.Exec(context.Background(), query, values...)
-	if err != nil {
-		log.Printf("EditTenderHandler: Failed to update tender: %v", err)
-		http.Error(w, "Failed to update tender", http.StatusInternalServerError)
+	// Обновление тендера с сохранением предыдущей версии
+	tender, err := Tenders.Edit(ctx, tenderId, update, user.ID)
+	if errors.Is(err, models.ErrAuctionHasBids) {
+		log.Printf("EditTenderHandler: Auction %s already has bids", tenderId)
+		response.Error(w, http.StatusConflict, response.CodeConflict, "Auction terms cannot change after bids are placed")
+		return
+	}
+	if errors.Is(err, models.ErrBidsOutsideBudget) {
+		log.Printf("EditTenderHandler: Existing bids do not fit the new budget of tender %s", tenderId)
+		response.Error(w, http.StatusConflict, response.CodeConflict, "Existing bids do not fit the tender budget")
 		return
 	}
-
-	// Возвращаем обновленную информацию о тендере
-	var tender Tender
-	err = conn.QueryRow(context.Background(), "SELECT id, name, description, service_type, status, version, created_at FROM tender WHERE id = $1", tenderId).Scan(
-		&tender.ID, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.Version, &tender.CreatedAt,
-	)
 	if err != nil {
-		log.Printf("EditTenderHandler: Failed to retrieve updated tender: %v", err)
-		http.Error(w, "Failed to retrieve updated tender", http.StatusInternalServerError)
+		log.Printf("EditTenderHandler: Failed to update tender: %v", err)
+		response.DBError(w, err, "Failed to update tender")
 		return
 	}
 
@@ -410,71 +446,41 @@ func EditTenderHandler(w http.ResponseWriter, r *http.Request) {
 // RollbackTenderHandler: Откат к предыдущей версии тендера
 func RollbackTenderHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
+	ctx := r.Context()
 	vars := mux.Vars(r)
 	tenderId := vars["tenderId"]
 	versionStr := vars["version"]
-	username := r.URL.Query().Get("username")
 
 	log.Printf("RollbackTenderHandler: Rolling back tender %s to version %s", tenderId, versionStr)
 
-	// Проверка существования пользователя
-	conn := db.GetConnection()
-	var userID string
-	err := conn.QueryRow(context.Background(), "SELECT id FROM employee WHERE username = $1", username).Scan(&userID)
-	if err != nil {
-		log.Printf("RollbackTenderHandler: User not found: %v", err)
-		http.Error(w, "User not found", http.StatusUnauthorized)
+	// Пользователь определяется по токену
+	user, ok := currentUser(ctx, w, "RollbackTenderHandler")
+	if !ok {
 		return
 	}
 
 	// Проверка прав пользователя на откат тендера
-	var responsibleID string
-	err = conn.QueryRow(context.Background(), `
-		SELECT id FROM organization_responsible
-		WHERE organization_id = (SELECT organization_id FROM tender WHERE id = $1)
-		AND user_id = $2`, tenderId, userID).Scan(&responsibleID)
-	if err != nil {
-		log.Printf("RollbackTenderHandler: User is not responsible for this tender: %v", err)
-		http.Error(w, "User is not responsible for this tender", http.StatusForbidden)
+	if _, ok = lookupResponsibleTender(ctx, w, "RollbackTenderHandler", tenderId); !ok {
 		return
 	}
 
-	// Проверка существования версии
 	version, err := strconv.Atoi(versionStr)
 	if err != nil {
 		log.Printf("RollbackTenderHandler: Invalid version format: %v", err)
-		http.Error(w, "Invalid version format", http.StatusBadRequest)
+		response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid version format")
 		return
 	}
 
-	var count int
-	err = conn.QueryRow(context.Background(), "SELECT COUNT(*) FROM tender_versions WHERE tender_id = $1 AND version = $2", tenderId, version).Scan(&count)
-	if err != nil || count == 0 {
+	// Откат к указанной версии и инкремент версии
+	tender, err := Tenders.Rollback(ctx, tenderId, version, user.ID)
+	if errors.Is(err, models.ErrNotFound) {
 		log.Printf("RollbackTenderHandler: Version not found for tender %s and version %d", tenderId, version)
-		http.Error(w, "Version not found", http.StatusNotFound)
+		response.Error(w, http.StatusNotFound, response.CodeVersionNotFound, "Version not found")
 		return
 	}
-
-	// Откат к указанной версии и инкремент версии
-	_, err = conn.Exec(context.Background(), `
-		UPDATE tender
-		SET name = v.name, description = v.description, service_type = v.service_type, version = version + 1, updated_at = CURRENT_TIMESTAMP
-		FROM tender_versions v
-		WHERE tender.id = $1 AND v.tender_id = $1 AND v.version = $2`, tenderId, version)
 	if err != nil {
 		log.Printf("RollbackTenderHandler: Failed to rollback tender: %v", err)
-		http.Error(w, "Failed to rollback tender", http.StatusInternalServerError)
-		return
-	}
-
-	// Возвращаем обновленную информацию о тендере
-	var tender Tender
-	err = conn.QueryRow(context.Background(), "SELECT id, name, description, service_type, status, version, created_at FROM tender WHERE id = $1", tenderId).Scan(
-		&tender.ID, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.Version, &tender.CreatedAt,
-	)
-	if err != nil {
-		log.Printf("RollbackTenderHandler: Failed to retrieve updated tender: %v", err)
-		http.Error(w, "Failed to retrieve updated tender", http.StatusInternalServerError)
+		response.DBError(w, err, "Failed to rollback tender")
 		return
 	}
 
@@ -484,3 +490,27 @@ func RollbackTenderHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("RollbackTenderHandler: Tender rolled back successfully in %v", time.Since(start))
 }
+
+// checkTenderSchedule проверяет срок подачи предложений и время публикации тендера.
+// Возвращает описание ошибки или пустую строку.
+func checkTenderSchedule(deadline, publishAt *time.Time) string {
+	if deadline != nil && !deadline.After(time.Now()) {
+		return "Submission deadline must be in the future"
+	}
+	if deadline != nil && publishAt != nil && !publishAt.Before(*deadline) {
+		return "Publication time must be before submission deadline"
+	}
+	return ""
+}
+
+// checkTenderBudget проверяет бюджет тендера: бюджет и валюта задаются только вместе.
+// Возвращает описание ошибки или пустую строку.
+func checkTenderBudget(budget *models.Amount, currency *string) string {
+	if (budget == nil) != (currency == nil) {
+		return "Budget and currency must be specified together"
+	}
+	if budget != nil {
+		return checkAmount("Budget", *budget)
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// ErrAmountPrecision возвращается для суммы с точностью больше копейки
+var ErrAmountPrecision = errors.New("amount must have at most two decimal places")
+
+// Amount - денежная сумма в сотых долях валюты (копейках). В базе хранится
+// как NUMERIC(15, 2), в JSON передается числом с двумя знаками после запятой.
+// Целое число копеек исключает ошибки округления при сравнении цен с бюджетом
+// и шагом аукциона.
+type Amount int64
+
+// ParseAmount разбирает десятичную запись суммы, например "1500", "99.9" или "1e3"
+func ParseAmount(s string) (Amount, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	r.Mul(r, big.NewRat(100, 1))
+	if !r.IsInt() {
+		return 0, ErrAmountPrecision
+	}
+	if !r.Num().IsInt64() {
+		return 0, fmt.Errorf("amount %q is out of range", s)
+	}
+	return Amount(r.Num().Int64()), nil
+}
+
+// String возвращает сумму с двумя знаками после запятой
+func (a Amount) String() string {
+	sign := ""
+	cents := int64(a)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	// null оставляет значение без изменений, как для встроенных типов
+	if string(data) == "null" {
+		return nil
+	}
+	// Сумма передается только числом
+	if _, err := strconv.ParseFloat(string(data), 64); err != nil {
+		return fmt.Errorf("amount must be a number, got %s", data)
+	}
+	amount, err := ParseAmount(string(data))
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// Value передает сумму в запрос строкой, которую PostgreSQL приводит к NUMERIC без потерь
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan читает NUMERIC, который pgx передает текстовой записью
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		amount, err := ParseAmount(v)
+		if err != nil {
+			return err
+		}
+		*a = amount
+		return nil
+	case []byte:
+		return a.Scan(string(v))
+	case int64:
+		*a = Amount(v * 100)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Amount", src)
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Amount
+		err  error
+	}{
+		{"1500", 150000, nil},
+		{"99.9", 9990, nil},
+		{"0.1", 10, nil},
+		{"0.29", 29, nil},
+		{"1e3", 100000, nil},
+		{"10.005", 0, ErrAmountPrecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAmount(tt.in)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseAmount(%q) error = %v, want %v", tt.in, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAmount(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	var input struct {
+		Price Amount `json:"price"`
+	}
+	if err := json.Unmarshal([]byte(`{"price":0.3}`), &input); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	// 0.1 + 0.2 в float64 не равно 0.3, в копейках - равно
+	if input.Price != Amount(10)+Amount(20) {
+		t.Errorf("price = %d, want 30", input.Price)
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(data) != `{"price":0.30}` {
+		t.Errorf("marshal = %s, want {\"price\":0.30}", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"price":"0.3"}`), &input); err == nil {
+		t.Error("expected a string amount to be rejected")
+	}
+}
+
+func TestAmountScan(t *testing.T) {
+	var a Amount
+	if err := a.Scan("1234.50"); err != nil || a != 123450 {
+		t.Errorf("Scan(\"1234.50\") = %d, %v", a, err)
+	}
+	if v, _ := a.Value(); v != "1234.50" {
+		t.Errorf("Value() = %v, want 1234.50", v)
+	}
+}
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	// ErrNotAuction возвращается при запросе состояния аукциона для обычного тендера
+	ErrNotAuction = errors.New("tender is not an auction")
+	// ErrAuctionStep возвращается, если ставка не снижает лучшую цену на шаг аукциона
+	ErrAuctionStep = errors.New("bid price must beat the best price by the auction step")
+	// ErrAuctionPriceRaise возвращается при попытке повысить цену своей ставки
+	ErrAuctionPriceRaise = errors.New("auction bid price can only be lowered")
+	// ErrAuctionRunning возвращается при попытке принять решение до окончания торгов
+	ErrAuctionRunning = errors.New("auction is still running")
+	// ErrNotBestBid возвращается при попытке согласовать ставку, не являющуюся лучшей
+	ErrNotBestBid = errors.New("only the best auction bid can be approved")
+	// ErrAuctionHasBids возвращается при изменении условий аукциона, в котором уже есть ставки
+	ErrAuctionHasBids = errors.New("auction terms cannot change after bids are placed")
+)
+
+// activeAuctionBid - условие на ставки, участвующие в торгах: опубликованные
+// и не отклоненные ответственными. Черновики в торгах не участвуют.
+const activeAuctionBid = "status = 'PUBLISHED' AND decision IS DISTINCT FROM 'Rejected'"
+
+// placeAuctionBid применяет правила аукциона к записанной в транзакции ставке:
+// торги должны идти, цена должна быть ниже лучшей цены остальных участников
+// не менее чем на шаг аукциона. Ставка в последние минуты торгов продлевает их.
+// previousPrice - цена ставки до изменения, nil для ставки, только что вступившей
+// в торги; повышать цену своей ставки нельзя.
+// Для обычных тендеров и неопубликованных предложений ничего не делает.
+func placeAuctionBid(ctx context.Context, tx pgx.Tx, bidID string, previousPrice *Amount) error {
+	var tenderID, tenderType, bidStatus string
+	var price Amount
+	err := tx.QueryRow(ctx, `
+		SELECT t.id, t.tender_type, b.status, b.price
+		FROM bids b JOIN tender t ON t.id = b.tender_id WHERE b.id = $1`, bidID).
+		Scan(&tenderID, &tenderType, &bidStatus, &price)
+	if err != nil {
+		return notFound(err)
+	}
+	if tenderType != TenderTypeAuction || bidStatus != BidStatusPublished {
+		return nil
+	}
+	if previousPrice != nil && price >= *previousPrice {
+		return ErrAuctionPriceRaise
+	}
+
+	// Блокировка тендера: ставки и продление торгов выполняются последовательно
+	var status string
+	var expired bool
+	err = tx.QueryRow(ctx, `
+		SELECT status, submission_deadline <= CURRENT_TIMESTAMP
+		FROM tender WHERE id = $1 FOR UPDATE`, tenderID).Scan(&status, &expired)
+	if err != nil {
+		return notFound(err)
+	}
+	if status != TenderStatusPublished {
+		return ErrTenderNotPublished
+	}
+	if expired {
+		return ErrDeadlinePassed
+	}
+
+	// Первая ставка ограничена только бюджетом, он проверяется отдельно
+	var beatsBest bool
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(b.price <= (
+			SELECT MIN(o.price) FROM bids o
+			WHERE o.tender_id = b.tender_id AND o.id <> b.id AND `+activeAuctionBid+`
+		) - t.auction_step, TRUE)
+		FROM bids b
+		JOIN tender t ON t.id = b.tender_id
+		WHERE b.id = $1`, bidID).Scan(&beatsBest)
+	if err != nil {
+		return err
+	}
+	if !beatsBest {
+		return ErrAuctionStep
+	}
+
+	// Защита от ставок в последний момент: торги продлеваются так,
+	// чтобы после ставки оставалось не меньше auction_extension_minutes
+	_, err = tx.Exec(ctx, `
+		UPDATE tender
+		SET submission_deadline = CURRENT_TIMESTAMP + make_interval(mins => auction_extension_minutes)
+		WHERE id = $1 AND submission_deadline < CURRENT_TIMESTAMP + make_interval(mins => auction_extension_minutes)`,
+		tenderID)
+	return err
+}
+
+// changesAuctionTerms сообщает, меняет ли изменение условия торгов:
+// срок, бюджет, валюту, шаг или продление
+func (u TenderUpdate) changesAuctionTerms() bool {
+	return u.SubmissionDeadline != nil || u.Budget != nil || u.Currency != nil ||
+		u.AuctionStep != nil || u.AuctionExtensionMinutes != nil
+}
+
+// checkAuctionTermsEditable возвращает ErrAuctionHasBids, если тендер - аукцион
+// с действующими ставками. Для обычных тендеров ничего не делает.
+func checkAuctionTermsEditable(ctx context.Context, tx pgx.Tx, tenderID string) error {
+	var hasBids bool
+	err := tx.QueryRow(ctx, `
+		SELECT t.tender_type = 'auction' AND EXISTS (
+			SELECT 1 FROM bids WHERE tender_id = t.id AND `+activeAuctionBid+`
+		)
+		FROM tender t
+		WHERE t.id = $1`, tenderID).Scan(&hasBids)
+	if err != nil {
+		return notFound(err)
+	}
+	if hasBids {
+		return ErrAuctionHasBids
+	}
+	return nil
+}
+
+// checkAuctionDecision проверяет решение по ставке аукциона: решения принимаются
+// после окончания торгов, согласовать можно только лучшую ставку.
+// Для обычных тендеров ничего не делает.
+func checkAuctionDecision(ctx context.Context, tx pgx.Tx, tenderID, bidID, decision string) error {
+	var isAuction, running bool
+	err := tx.QueryRow(ctx, `
+		SELECT tender_type = 'auction', COALESCE(submission_deadline > CURRENT_TIMESTAMP, FALSE)
+		FROM tender WHERE id = $1`, tenderID).Scan(&isAuction, &running)
+	if err != nil {
+		return notFound(err)
+	}
+	if !isAuction {
+		return nil
+	}
+	if running {
+		return ErrAuctionRunning
+	}
+	if decision != DecisionApproved {
+		return nil
+	}
+
+	// Лучшая ставка - самая низкая цена, при равной цене - более ранняя
+	var bestID string
+	err = tx.QueryRow(ctx, `
+		SELECT id FROM bids
+		WHERE tender_id = $1 AND `+activeAuctionBid+`
+		ORDER BY price ASC, created_at ASC, id ASC
+		LIMIT 1`, tenderID).Scan(&bestID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Опубликованных ставок нет, согласовывать нечего
+		return ErrNotBestBid
+	}
+	if err != nil {
+		return err
+	}
+	if bestID != bidID {
+		return ErrNotBestBid
+	}
+	return nil
+}
+
+func (r *PostgresTenderRepository) AuctionState(ctx context.Context, id string) (*AuctionState, error) {
+	var tenderType string
+	var endsAt *time.Time
+	var startPrice, step *Amount
+	var currency *string
+	state := AuctionState{TenderID: id}
+	err := r.db.QueryRow(ctx, `
+		SELECT t.status, t.tender_type, t.submission_deadline, t.budget, t.auction_step, t.currency,
+			t.status = 'PUBLISHED' AND COALESCE(t.submission_deadline > CURRENT_TIMESTAMP, FALSE),
+			(SELECT MIN(price) FROM bids WHERE tender_id = t.id AND `+activeAuctionBid+`),
+			(SELECT COUNT(*) FROM bids WHERE tender_id = t.id AND `+activeAuctionBid+`)
+		FROM tender t
+		WHERE t.id = $1`, id).
+		Scan(&state.Status, &tenderType, &endsAt, &startPrice, &step, &currency,
+			&state.Running, &state.BestPrice, &state.BidsCount)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if tenderType != TenderTypeAuction {
+		return nil, ErrNotAuction
+	}
+
+	// Параметры аукциона обязательны, что гарантирует tender_auction_check
+	state.EndsAt, state.StartPrice, state.Step, state.Currency = *endsAt, *startPrice, *step, *currency
+	return &state, nil
+}
@@ -0,0 +1,540 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresBidRepository - реализация BidRepository для PostgreSQL
+type PostgresBidRepository struct {
+	db DB
+}
+
+// NewBidRepository создает репозиторий предложений
+func NewBidRepository(db DB) *PostgresBidRepository {
+	return &PostgresBidRepository{db: db}
+}
+
+const bidColumns = "id, name, description, status, tender_id, author_type, author_id, version, created_at, price, currency, delivery_terms"
+
+// bidFields возвращает приемники для столбцов bidColumns и дополнительных столбцов extra
+func bidFields(b *Bid, extra ...interface{}) []interface{} {
+	return append([]interface{}{&b.ID, &b.Name, &b.Description, &b.Status, &b.TenderID, &b.AuthorType, &b.AuthorID, &b.Version, &b.CreatedAt,
+		&b.Price, &b.Currency, &b.DeliveryTerms}, extra...)
+}
+
+func scanBid(row pgx.Row) (*Bid, error) {
+	var b Bid
+	if err := row.Scan(bidFields(&b)...); err != nil {
+		return nil, notFound(err)
+	}
+	return &b, nil
+}
+
+func (r *PostgresBidRepository) queryBids(ctx context.Context, query string, args ...interface{}) ([]Bid, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bids := []Bid{}
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, *bid)
+	}
+	return bids, rows.Err()
+}
+
+func (r *PostgresBidRepository) Create(ctx context.Context, bid *Bid) error {
+	bid.Status = BidStatusCreated
+	bid.Version = 1
+	return inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		// Предложения принимаются только по опубликованным тендерам;
+		// блокировка не дает закрыть тендер до фиксации предложения
+		if err := requirePublishedTender(ctx, tx, bid.TenderID); err != nil {
+			return err
+		}
+
+		err := tx.QueryRow(ctx, `
+			INSERT INTO bids (name, description, status, tender_id, author_type, author_id, version,
+				price, currency, delivery_terms, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP)
+			RETURNING id, created_at`,
+			bid.Name, bid.Description, bid.Status, bid.TenderID, bid.AuthorType, bid.AuthorID, bid.Version,
+			bid.Price, bid.Currency, bid.DeliveryTerms).
+			Scan(&bid.ID, &bid.CreatedAt)
+		if err != nil {
+			return err
+		}
+		return checkBidBudget(ctx, tx, bid.ID)
+	})
+}
+
+func (r *PostgresBidRepository) GetByID(ctx context.Context, id string) (*Bid, error) {
+	// Вместе с предложением загружаются организации автора-пользователя для проверки доступа
+	bid := &Bid{}
+	err := r.db.QueryRow(ctx, `
+		SELECT `+bidColumns+`,
+			ARRAY(SELECT organization_id::text FROM organization_responsible WHERE user_id = bids.author_id)
+		FROM bids WHERE id = $1`, id).
+		Scan(bidFields(bid, &bid.AuthorOrganizationIDs)...)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return bid, nil
+}
+
+func (r *PostgresBidRepository) ListByAuthor(ctx context.Context, authorID string, limit, offset int) ([]Bid, error) {
+	return r.queryBids(ctx, `
+		SELECT `+bidColumns+`
+		FROM bids
+		WHERE author_id = $1
+		ORDER BY name ASC
+		LIMIT $2 OFFSET $3`, authorID, limit, offset)
+}
+
+// bidOrders - выражения ORDER BY для порядков сортировки предложений
+var bidOrders = map[string]string{
+	BidSortName:      "name ASC, id ASC",
+	BidSortPrice:     "price ASC, created_at ASC, id ASC",
+	BidSortPriceDesc: "price DESC, created_at ASC, id ASC",
+}
+
+func (r *PostgresBidRepository) ListByTender(ctx context.Context, tenderID, sortBy string, limit, offset int) ([]Bid, error) {
+	order, ok := bidOrders[sortBy]
+	if !ok {
+		order = bidOrders[BidSortName]
+	}
+	return r.queryBids(ctx, `
+		SELECT `+bidColumns+`
+		FROM bids
+		WHERE tender_id = $1
+		ORDER BY `+order+`
+		LIMIT $2 OFFSET $3`, tenderID, limit, offset)
+}
+
+func (r *PostgresBidRepository) UpdateStatus(ctx context.Context, id, status string) (*Bid, error) {
+	var bid *Bid
+	err := inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		locked, err := lockUndecidedBid(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// Опубликовать или восстановить из отмены предложение можно только по
+		// опубликованному тендеру: отмененные при закрытии тендера не оживают
+		if status == BidStatusPublished || (locked.status == BidStatusCanceled && status != BidStatusCanceled) {
+			if err = requirePublishedTender(ctx, tx, locked.tenderID); err != nil {
+				return err
+			}
+		}
+
+		bid, err = scanBid(tx.QueryRow(ctx, "UPDATE bids SET status = $1 WHERE id = $2 RETURNING "+bidColumns, status, id))
+		if err != nil {
+			return err
+		}
+
+		// Опубликованное предложение вступает в торги аукциона как новая ставка
+		if status == BidStatusPublished && locked.status != BidStatusPublished {
+			return placeAuctionBid(ctx, tx, id, nil)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bid, nil
+}
+
+// saveBidVersion сохраняет текущее состояние предложения в bid_versions
+// в рамках переданной транзакции
+func saveBidVersion(ctx context.Context, tx pgx.Tx, bidID, userID string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO bid_versions (bid_id, version, name, description, price, currency, delivery_terms, changed_by)
+		SELECT id, version, name, description, price, currency, delivery_terms, $2 FROM bids WHERE id = $1
+		ON CONFLICT (bid_id, version) DO NOTHING`, bidID, userID)
+	return err
+}
+
+func (r *PostgresBidRepository) Edit(ctx context.Context, id string, update BidUpdate, changedBy string) (*Bid, error) {
+	// Построение запроса на обновление
+	var fields []string
+	var values []interface{}
+
+	if update.Name != nil {
+		values = append(values, *update.Name)
+		fields = append(fields, "name = $"+strconv.Itoa(len(values)))
+	}
+	if update.Description != nil {
+		values = append(values, *update.Description)
+		fields = append(fields, "description = $"+strconv.Itoa(len(values)))
+	}
+	if update.Price != nil {
+		values = append(values, *update.Price)
+		fields = append(fields, "price = $"+strconv.Itoa(len(values)))
+	}
+	if update.Currency != nil {
+		values = append(values, *update.Currency)
+		fields = append(fields, "currency = $"+strconv.Itoa(len(values)))
+	}
+	if update.DeliveryTerms != nil {
+		values = append(values, *update.DeliveryTerms)
+		fields = append(fields, "delivery_terms = $"+strconv.Itoa(len(values)))
+	}
+
+	// Инкремент версии и добавление в запрос
+	fields = append(fields, "version = version + 1")
+	values = append(values, id)
+	query := "UPDATE bids SET " + strings.Join(fields, ", ") + " WHERE id = $" + strconv.Itoa(len(values)) +
+		" RETURNING " + bidColumns
+
+	var bid *Bid
+	err := inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		locked, err := lockEditableBid(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// Изменять предложение можно, пока тендер принимает предложения
+		if err = requirePublishedTender(ctx, tx, locked.tenderID); err != nil {
+			return err
+		}
+
+		// Сохранение текущей версии предложения перед изменением
+		if err = saveBidVersion(ctx, tx, id, changedBy); err != nil {
+			return err
+		}
+
+		bid, err = scanBid(tx.QueryRow(ctx, query, values...))
+		if err != nil {
+			return err
+		}
+		if err = checkBidBudget(ctx, tx, id); err != nil {
+			return err
+		}
+
+		// Изменение цены опубликованного предложения в аукционе - новая ставка
+		if update.Price != nil {
+			return placeAuctionBid(ctx, tx, id, &locked.price)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bid, nil
+}
+
+func (r *PostgresBidRepository) Rollback(ctx context.Context, id string, version int, changedBy string) (*Bid, error) {
+	var bid *Bid
+	err := inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		locked, err := lockEditableBid(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// Откатывать предложение можно, пока тендер принимает предложения
+		if err = requirePublishedTender(ctx, tx, locked.tenderID); err != nil {
+			return err
+		}
+
+		// Проверка существования версии
+		var exists bool
+		err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM bid_versions WHERE bid_id = $1 AND version = $2)", id, version).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNotFound
+		}
+
+		// Сохранение текущей версии предложения перед откатом
+		if err = saveBidVersion(ctx, tx, id, changedBy); err != nil {
+			return err
+		}
+
+		// Откат к указанной версии и инкремент версии
+		// Цена и условия старых версий не сохранены, в этом случае они не меняются
+		bid, err = scanBid(tx.QueryRow(ctx, `
+			UPDATE bids
+			SET name = v.name, description = v.description,
+				price = COALESCE(v.price, bids.price), currency = COALESCE(v.currency, bids.currency),
+				delivery_terms = COALESCE(v.delivery_terms, bids.delivery_terms),
+				version = bids.version + 1
+			FROM bid_versions v
+			WHERE bids.id = $1 AND v.bid_id = $1 AND v.version = $2
+			RETURNING bids.id, bids.name, bids.description, bids.status, bids.tender_id, bids.author_type, bids.author_id, bids.version, bids.created_at,
+				bids.price, bids.currency, bids.delivery_terms`,
+			id, version))
+		if err != nil {
+			return err
+		}
+		if err = checkBidBudget(ctx, tx, id); err != nil {
+			return err
+		}
+
+		// Откат к другой цене опубликованного предложения в аукционе - новая ставка
+		if bid.Price != locked.price {
+			return placeAuctionBid(ctx, tx, id, &locked.price)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bid, nil
+}
+
+// lockedBid - состояние предложения, заблокированного в транзакции
+type lockedBid struct {
+	tenderID string
+	status   string
+	price    Amount
+}
+
+// lockUndecidedBid блокирует строку предложения до конца транзакции и возвращает
+// его состояние до изменения. Предложение с решением изменять нельзя: ErrBidDecided.
+func lockUndecidedBid(ctx context.Context, tx pgx.Tx, id string) (lockedBid, error) {
+	var locked lockedBid
+	var decision *string
+	err := tx.QueryRow(ctx, "SELECT tender_id, status, price, decision FROM bids WHERE id = $1 FOR UPDATE", id).
+		Scan(&locked.tenderID, &locked.status, &locked.price, &decision)
+	if err != nil {
+		return locked, notFound(err)
+	}
+	if decision != nil {
+		return locked, ErrBidDecided
+	}
+	return locked, nil
+}
+
+// lockEditableBid блокирует предложение для изменения или отката. Кроме решения
+// запрещен статус CANCELED: отмененное предложение возвращается в работу только
+// сменой статуса, а не новой версией.
+func lockEditableBid(ctx context.Context, tx pgx.Tx, id string) (lockedBid, error) {
+	locked, err := lockUndecidedBid(ctx, tx, id)
+	if err != nil {
+		return locked, err
+	}
+	if locked.status == BidStatusCanceled {
+		return locked, ErrBidCanceled
+	}
+	return locked, nil
+}
+
+// checkBidBudget проверяет записанное в транзакции предложение по бюджету тендера:
+// валюта должна совпадать с валютой бюджета, цена - не превышать его
+func checkBidBudget(ctx context.Context, tx pgx.Tx, bidID string) error {
+	var sameCurrency, withinBudget bool
+	err := tx.QueryRow(ctx, `
+		SELECT b.currency = t.currency, b.price <= t.budget
+		FROM bids b
+		JOIN tender t ON t.id = b.tender_id
+		WHERE b.id = $1 AND t.budget IS NOT NULL`, bidID).Scan(&sameCurrency, &withinBudget)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Бюджет тендера не ограничен
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !sameCurrency {
+		return ErrCurrencyMismatch
+	}
+	if !withinBudget {
+		return ErrBudgetExceeded
+	}
+	return nil
+}
+
+// requirePublishedTender блокирует тендер от изменения статуса до конца транзакции
+// и проверяет, что он опубликован и срок подачи предложений не истек
+func requirePublishedTender(ctx context.Context, tx pgx.Tx, tenderID string) error {
+	var status string
+	var expired bool
+	err := tx.QueryRow(ctx, `
+		SELECT status, COALESCE(submission_deadline <= CURRENT_TIMESTAMP, FALSE)
+		FROM tender WHERE id = $1 FOR SHARE`, tenderID).Scan(&status, &expired)
+	if err != nil {
+		return notFound(err)
+	}
+	if status != TenderStatusPublished {
+		return ErrTenderNotPublished
+	}
+	if expired {
+		return ErrDeadlinePassed
+	}
+	return nil
+}
+
+func (r *PostgresBidRepository) SubmitDecision(ctx context.Context, id, userID, decision string) (*Bid, DecisionTally, error) {
+	var bid *Bid
+	var tally DecisionTally
+	err := inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		var err error
+		bid, tally, err = submitDecision(ctx, tx, id, userID, decision)
+		return err
+	})
+	if err != nil {
+		return nil, tally, err
+	}
+	return bid, tally, nil
+}
+
+// submitDecision записывает решение ответственного в транзакции и подводит итог по кворуму.
+// Решение по предложению с итоговым решением не принимается: ErrBidDecided, поэтому
+// отклонившее предложение решение нельзя заменить согласованием.
+func submitDecision(ctx context.Context, tx pgx.Tx, id, userID, decision string) (*Bid, DecisionTally, error) {
+	var tally DecisionTally
+
+	// Блокировка предложения и его тендера: параллельные решения
+	// по тендеру выполняются последовательно
+	var tenderID, organizationID, tenderStatus, status string
+	var currentDecision *string
+	err := tx.QueryRow(ctx, "SELECT tender_id, status, decision FROM bids WHERE id = $1 FOR UPDATE", id).
+		Scan(&tenderID, &status, &currentDecision)
+	if err != nil {
+		return nil, tally, notFound(err)
+	}
+
+	// Итоговое решение окончательно, рассматриваются только опубликованные предложения:
+	// черновик автора согласовать нельзя
+	if currentDecision != nil {
+		return nil, tally, ErrBidDecided
+	}
+	if status == BidStatusCanceled {
+		return nil, tally, ErrBidCanceled
+	}
+	if status != BidStatusPublished {
+		return nil, tally, ErrBidNotPublished
+	}
+	err = tx.QueryRow(ctx, "SELECT organization_id, status FROM tender WHERE id = $1 FOR UPDATE", tenderID).
+		Scan(&organizationID, &tenderStatus)
+	if err != nil {
+		return nil, tally, notFound(err)
+	}
+
+	// Решение может принять только ответственный за организацию тендера
+	var isResponsible bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM organization_responsible WHERE organization_id = $1 AND user_id = $2)`,
+		organizationID, userID).Scan(&isResponsible)
+	if err != nil {
+		return nil, tally, err
+	}
+	if !isResponsible {
+		return nil, tally, ErrForbidden
+	}
+
+	// В аукционе решения принимаются после торгов, согласовать можно только лучшую ставку
+	if err = checkAuctionDecision(ctx, tx, tenderID, id, decision); err != nil {
+		return nil, tally, err
+	}
+
+	// Сохранение решения ответственного (повторное решение до подведения итога заменяет предыдущее)
+	_, err = tx.Exec(ctx, `
+		INSERT INTO bid_decisions (bid_id, user_id, decision)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (bid_id, user_id) DO UPDATE SET decision = EXCLUDED.decision, created_at = CURRENT_TIMESTAMP`,
+		id, userID, decision)
+	if err != nil {
+		return nil, tally, err
+	}
+
+	// Подсчет решений и кворума: кворум = min(3, количество активных ответственных за организацию).
+	// Голоса отключенных сотрудников не учитываются ни в кворуме, ни в числе решений.
+	err = tx.QueryRow(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE d.decision = 'Approved'),
+			COUNT(*) FILTER (WHERE d.decision = 'Rejected'),
+			LEAST(3, (
+				SELECT COUNT(*) FROM organization_responsible orp
+				INNER JOIN employee e ON e.id = orp.user_id
+				WHERE orp.organization_id = $2 AND e.is_active
+			))
+		FROM bid_decisions d
+		INNER JOIN employee e ON e.id = d.user_id
+		WHERE d.bid_id = $1 AND e.is_active`, id, organizationID).Scan(&tally.Approvals, &tally.Rejections, &tally.Quorum)
+	if err != nil {
+		return nil, tally, err
+	}
+
+	// Хотя бы одно отклонение отклоняет предложение,
+	// согласование наступает при достижении кворума
+	if tally.Rejections > 0 {
+		_, err = tx.Exec(ctx, "UPDATE bids SET decision = 'Rejected' WHERE id = $1", id)
+		if err != nil {
+			return nil, tally, err
+		}
+	} else if tally.Approvals >= tally.Quorum {
+		_, err = tx.Exec(ctx, "UPDATE bids SET decision = 'Approved' WHERE id = $1", id)
+		if err != nil {
+			return nil, tally, err
+		}
+
+		// Предложение согласовано, закрываем тендер по правилам конечного автомата.
+		// Тендер, закрытый по сроку, уже закрыт: остальные предложения снимаются с рассмотрения.
+		if tenderStatus == TenderStatusPublished {
+			_, err = transitionTender(ctx, tx, tenderID, TenderStatusClosed, "Bid "+id+" approved", userID)
+		} else {
+			_, err = tx.Exec(ctx, `
+				UPDATE bids SET status = 'CANCELED'
+				WHERE tender_id = $1 AND decision IS NULL AND status <> 'CANCELED'`, tenderID)
+		}
+		if err != nil {
+			return nil, tally, err
+		}
+	}
+
+	// Возвращаем обновленные данные предложения
+	bid, err := scanBid(tx.QueryRow(ctx, "SELECT "+bidColumns+" FROM bids WHERE id = $1", id))
+	if err != nil {
+		return nil, tally, err
+	}
+	return bid, tally, nil
+}
+
+func (r *PostgresBidRepository) AddFeedback(ctx context.Context, id, userID, feedback string) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO bid_feedback (bid_id, user_id, description)
+		VALUES ($1, $2, $3)`, id, userID, feedback)
+	return err
+}
+
+func (r *PostgresBidRepository) HasAuthorBidForTender(ctx context.Context, tenderID, authorID string) (bool, error) {
+	var hasBid bool
+	err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM bids WHERE tender_id = $1 AND author_id = $2)", tenderID, authorID).Scan(&hasBid)
+	return hasBid, err
+}
+
+func (r *PostgresBidRepository) ListReviewsByAuthor(ctx context.Context, authorID string, limit, offset int) ([]BidReview, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT f.id, f.description, f.created_at
+		FROM bid_feedback AS f
+		INNER JOIN bids ON bids.id = f.bid_id
+		WHERE bids.author_id = $1
+		ORDER BY f.created_at DESC
+		LIMIT $2 OFFSET $3`, authorID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []BidReview{}
+	for rows.Next() {
+		var review BidReview
+		if err = rows.Scan(&review.ID, &review.Description, &review.CreatedAt); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, rows.Err()
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSubmitDecisionRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision string
+		rows     []fakeRow
+		want     error
+	}{
+		{
+			name:     "approve rejected bid",
+			decision: DecisionApproved,
+			rows:     []fakeRow{{"tender-1", BidStatusPublished, DecisionRejected}},
+			want:     ErrBidDecided,
+		},
+		{
+			name:     "reject approved bid",
+			decision: DecisionRejected,
+			rows:     []fakeRow{{"tender-1", BidStatusPublished, DecisionApproved}},
+			want:     ErrBidDecided,
+		},
+		{
+			name:     "decide canceled bid",
+			decision: DecisionApproved,
+			rows:     []fakeRow{{"tender-1", BidStatusCanceled, nil}},
+			want:     ErrBidCanceled,
+		},
+		{
+			name:     "approve draft bid",
+			decision: DecisionApproved,
+			rows:     []fakeRow{{"tender-1", BidStatusCreated, nil}},
+			want:     ErrBidNotPublished,
+		},
+		{
+			name:     "not responsible",
+			decision: DecisionApproved,
+			rows:     []fakeRow{{"tender-1", BidStatusPublished, nil}, {"org-1", TenderStatusPublished}, {false}},
+			want:     ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &fakeTx{rows: tt.rows}
+
+			bid, _, err := submitDecision(context.Background(), tx, "bid-1", "user-1", tt.decision)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("submitDecision() error = %v, want %v", err, tt.want)
+			}
+			if bid != nil {
+				t.Errorf("submitDecision() returned bid %v on error", bid)
+			}
+			if len(tx.rows) != 0 {
+				t.Errorf("%d expected queries were not executed", len(tx.rows))
+			}
+		})
+	}
+}
@@ -1 +1,159 @@
 package models
+
+import "time"
+
+// Статусы тендера
+const (
+	TenderStatusCreated   = "CREATED"
+	TenderStatusPublished = "PUBLISHED"
+	TenderStatusClosed    = "CLOSED"
+)
+
+// Типы тендера
+const (
+	TenderTypeStandard = "standard" // предложения подаются один раз и рассматриваются ответственными
+	TenderTypeAuction  = "auction"  // обратный аукцион: цена снижается в течение окна торгов
+)
+
+// Статусы предложения
+const (
+	BidStatusCreated   = "CREATED"
+	BidStatusPublished = "PUBLISHED"
+	BidStatusCanceled  = "CANCELED"
+)
+
+// Решения по предложению
+const (
+	DecisionApproved = "Approved"
+	DecisionRejected = "Rejected"
+)
+
+// Порядок сортировки предложений тендера
+const (
+	BidSortName      = "name"
+	BidSortPrice     = "price"      // сначала самые дешевые
+	BidSortPriceDesc = "price_desc" // сначала самые дорогие
+)
+
+// Тип автора предложения
+const (
+	AuthorTypeOrganization = "Organization"
+	AuthorTypeUser         = "User"
+)
+
+// Типы организаций (enum organization_type)
+const (
+	OrganizationTypeIE  = "IE"
+	OrganizationTypeLLC = "LLC"
+	OrganizationTypeJSC = "JSC"
+)
+
+// Employee - сотрудник
+type Employee struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	FirstName *string   `json:"firstName"`
+	LastName  *string   `json:"lastName"`
+	IsAdmin   bool      `json:"isAdmin"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Organization - организация
+type Organization struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	Type        *string   `json:"type"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Tender - тендер организации
+type Tender struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	ServiceType    string    `json:"serviceType"`
+	Status         string    `json:"status"`
+	OrganizationID string    `json:"organizationId"`
+	CreatorID      string    `json:"-"`
+	Version        int       `json:"version"`
+	CreatedAt      time.Time `json:"createdAt"`
+	// Срок подачи предложений и время автоматической публикации; nil - не заданы
+	SubmissionDeadline *time.Time `json:"submissionDeadline,omitempty"`
+	PublishAt          *time.Time `json:"publishAt,omitempty"`
+	// Максимальный бюджет и его валюта; nil - бюджет не ограничен
+	Budget   *Amount `json:"budget,omitempty"`
+	Currency *string `json:"currency,omitempty"`
+	// Тип тендера и параметры аукциона: шаг снижения цены и продление торгов
+	Type                    string  `json:"type"`
+	AuctionStep             *Amount `json:"auctionStep,omitempty"`
+	AuctionExtensionMinutes *int    `json:"auctionExtensionMinutes,omitempty"`
+}
+
+// TenderVersion - сохраненная версия тендера
+type TenderVersion struct {
+	Version     int        `json:"version"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	ServiceType string     `json:"serviceType"`
+	ChangedBy   *string    `json:"changedBy"`
+	ChangedAt   *time.Time `json:"changedAt"`
+}
+
+// TenderStatusChange - запись о переходе тендера между статусами
+type TenderStatusChange struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Reason    *string   `json:"reason"`
+	ChangedBy *string   `json:"changedBy"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+// AuctionState - текущее состояние обратного аукциона, доступное участникам
+type AuctionState struct {
+	TenderID   string    `json:"tenderId"`
+	Status     string    `json:"status"`
+	Running    bool      `json:"running"`
+	EndsAt     time.Time `json:"endsAt"`
+	StartPrice Amount    `json:"startPrice"`
+	Step       Amount    `json:"step"`
+	Currency   string    `json:"currency"`
+	BestPrice  *Amount   `json:"bestPrice"`
+	BidsCount  int       `json:"bidsCount"`
+}
+
+// Bid - предложение по тендеру
+type Bid struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	TenderID    string    `json:"tenderId"`
+	AuthorType  string    `json:"authorType"`
+	AuthorID    string    `json:"authorId"`
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"createdAt"`
+	// Цена в валюте currency и условия поставки
+	Price         Amount `json:"price"`
+	Currency      string `json:"currency"`
+	DeliveryTerms string `json:"deliveryTerms"`
+	// Организации, за которые отвечает автор-пользователь; заполняется только GetByID
+	AuthorOrganizationIDs []string `json:"-"`
+}
+
+// BidReview - отзыв ответственного о предложении
+type BidReview struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// DecisionTally содержит итоги голосования ответственных по предложению
+type DecisionTally struct {
+	Approvals  int
+	Rejections int
+	Quorum     int
+}
@@ -0,0 +1,200 @@
+package models
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresOrganizationRepository - реализация OrganizationRepository для PostgreSQL
+type PostgresOrganizationRepository struct {
+	db DB
+}
+
+// NewOrganizationRepository создает репозиторий организаций
+func NewOrganizationRepository(db DB) *PostgresOrganizationRepository {
+	return &PostgresOrganizationRepository{db: db}
+}
+
+const organizationColumns = "id, name, description, type::text, created_at, updated_at"
+
+func scanOrganization(row pgx.Row) (*Organization, error) {
+	var o Organization
+	err := row.Scan(&o.ID, &o.Name, &o.Description, &o.Type, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &o, nil
+}
+
+func (r *PostgresOrganizationRepository) List(ctx context.Context, limit, offset int) ([]Organization, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+organizationColumns+`
+		FROM organization
+		ORDER BY name ASC, id ASC
+		LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizations := []Organization{}
+	for rows.Next() {
+		organization, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, *organization)
+	}
+	return organizations, rows.Err()
+}
+
+func (r *PostgresOrganizationRepository) GetByID(ctx context.Context, id string) (*Organization, error) {
+	return scanOrganization(r.db.QueryRow(ctx, "SELECT "+organizationColumns+" FROM organization WHERE id = $1", id))
+}
+
+func (r *PostgresOrganizationRepository) Create(ctx context.Context, organization *Organization, creatorID string) error {
+	return inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO organization (name, description, type)
+			VALUES ($1, $2, $3)
+			RETURNING id, created_at, updated_at`,
+			organization.Name, organization.Description, organization.Type).
+			Scan(&organization.ID, &organization.CreatedAt, &organization.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		// Создатель организации становится ее первым ответственным
+		_, err = tx.Exec(ctx, `
+			INSERT INTO organization_responsible (organization_id, user_id)
+			VALUES ($1, $2)`, organization.ID, creatorID)
+		return err
+	})
+}
+
+func (r *PostgresOrganizationRepository) Update(ctx context.Context, id string, update OrganizationUpdate) (*Organization, error) {
+	// Построение запроса на обновление
+	var fields []string
+	var values []interface{}
+
+	if update.Name != nil {
+		values = append(values, *update.Name)
+		fields = append(fields, "name = $"+strconv.Itoa(len(values)))
+	}
+	if update.Description != nil {
+		values = append(values, *update.Description)
+		fields = append(fields, "description = $"+strconv.Itoa(len(values)))
+	}
+	if update.Type != nil {
+		values = append(values, *update.Type)
+		fields = append(fields, "type = $"+strconv.Itoa(len(values)))
+	}
+
+	fields = append(fields, "updated_at = CURRENT_TIMESTAMP")
+	values = append(values, id)
+	query := "UPDATE organization SET " + strings.Join(fields, ", ") + " WHERE id = $" + strconv.Itoa(len(values)) +
+		" RETURNING " + organizationColumns
+
+	return scanOrganization(r.db.QueryRow(ctx, query, values...))
+}
+
+func (r *PostgresOrganizationRepository) Delete(ctx context.Context, id string) error {
+	return inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		var lockedID string
+		err := tx.QueryRow(ctx, "SELECT id FROM organization WHERE id = $1 FOR UPDATE", id).Scan(&lockedID)
+		if err != nil {
+			return notFound(err)
+		}
+
+		// Удаление организации каскадно удалило бы ее тендеры и историю
+		var hasTenders bool
+		err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM tender WHERE organization_id = $1)", id).Scan(&hasTenders)
+		if err != nil {
+			return err
+		}
+		if hasTenders {
+			return ErrConflict
+		}
+
+		_, err = tx.Exec(ctx, "DELETE FROM organization WHERE id = $1", id)
+		return err
+	})
+}
+
+func (r *PostgresOrganizationRepository) ListResponsibles(ctx context.Context, id string) ([]Employee, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT e.id, e.username, e.first_name, e.last_name, e.is_admin, e.is_active, e.created_at, e.updated_at
+		FROM organization_responsible orp
+		INNER JOIN employee e ON e.id = orp.user_id
+		WHERE orp.organization_id = $1
+		ORDER BY e.username ASC`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	employees := []Employee{}
+	for rows.Next() {
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			return nil, err
+		}
+		employees = append(employees, *employee)
+	}
+	return employees, rows.Err()
+}
+
+func (r *PostgresOrganizationRepository) AddResponsible(ctx context.Context, id, userID string) error {
+	// Уникальность пары гарантирует ограничение: параллельные назначения не создают дубликатов
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO organization_responsible (organization_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (organization_id, user_id) DO NOTHING`, id, userID)
+	return err
+}
+
+func (r *PostgresOrganizationRepository) RemoveResponsible(ctx context.Context, id, userID string) error {
+	return inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		var responsibleID string
+		err := tx.QueryRow(ctx, `
+			SELECT id FROM organization_responsible
+			WHERE organization_id = $1 AND user_id = $2
+			FOR UPDATE`, id, userID).Scan(&responsibleID)
+		if err != nil {
+			return notFound(err)
+		}
+
+		// tender.responsible_id ссылается на запись с ON DELETE CASCADE:
+		// отвязываем тендеры, чтобы они не были удалены вместе с записью
+		_, err = tx.Exec(ctx, "UPDATE tender SET responsible_id = NULL WHERE responsible_id = $1", responsibleID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "DELETE FROM organization_responsible WHERE id = $1", responsibleID)
+		return err
+	})
+}
+
+func (r *PostgresOrganizationRepository) ListResponsibilities(ctx context.Context, userID string) (map[string]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT organization_id, id FROM organization_responsible
+		WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	responsibilities := map[string]string{}
+	for rows.Next() {
+		var organizationID, responsibleID string
+		if err = rows.Scan(&organizationID, &responsibleID); err != nil {
+			return nil, err
+		}
+		responsibilities[organizationID] = responsibleID
+	}
+	return responsibilities, rows.Err()
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// uniqueViolationCode - SQLSTATE нарушения ограничения уникальности
+const uniqueViolationCode = "23505"
+
+// notFound заменяет pgx.ErrNoRows на ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// conflict заменяет нарушение уникальности на ErrConflict
+func conflict(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return ErrConflict
+	}
+	return err
+}
+
+// PostgresEmployeeRepository - реализация EmployeeRepository для PostgreSQL
+type PostgresEmployeeRepository struct {
+	db DB
+}
+
+// NewEmployeeRepository создает репозиторий сотрудников
+func NewEmployeeRepository(db DB) *PostgresEmployeeRepository {
+	return &PostgresEmployeeRepository{db: db}
+}
+
+const employeeColumns = "id, username, first_name, last_name, is_admin, is_active, created_at, updated_at"
+
+func scanEmployee(row pgx.Row) (*Employee, error) {
+	var e Employee
+	err := row.Scan(&e.ID, &e.Username, &e.FirstName, &e.LastName, &e.IsAdmin, &e.IsActive, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &e, nil
+}
+
+func (r *PostgresEmployeeRepository) GetByUsername(ctx context.Context, username string) (*Employee, error) {
+	return scanEmployee(r.db.QueryRow(ctx, "SELECT "+employeeColumns+" FROM employee WHERE username = $1", username))
+}
+
+func (r *PostgresEmployeeRepository) GetByID(ctx context.Context, id string) (*Employee, error) {
+	return scanEmployee(r.db.QueryRow(ctx, "SELECT "+employeeColumns+" FROM employee WHERE id = $1", id))
+}
+
+func (r *PostgresEmployeeRepository) GetPasswordHash(ctx context.Context, id string) (string, error) {
+	var passwordHash *string
+	err := r.db.QueryRow(ctx, "SELECT password_hash FROM employee WHERE id = $1", id).Scan(&passwordHash)
+	if err != nil {
+		return "", notFound(err)
+	}
+	if passwordHash == nil {
+		return "", ErrNotFound
+	}
+	return *passwordHash, nil
+}
+
+func (r *PostgresEmployeeRepository) Create(ctx context.Context, employee *Employee, passwordHash string) error {
+	employee.IsActive = true
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO employee (username, first_name, last_name, password_hash)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at`,
+		employee.Username, employee.FirstName, employee.LastName, passwordHash).
+		Scan(&employee.ID, &employee.CreatedAt, &employee.UpdatedAt)
+	return conflict(err)
+}
+
+func (r *PostgresEmployeeRepository) Update(ctx context.Context, id string, update EmployeeUpdate) (*Employee, error) {
+	// Построение запроса на обновление
+	var fields []string
+	var values []interface{}
+
+	if update.FirstName != nil {
+		values = append(values, *update.FirstName)
+		fields = append(fields, "first_name = $"+strconv.Itoa(len(values)))
+	}
+	if update.LastName != nil {
+		values = append(values, *update.LastName)
+		fields = append(fields, "last_name = $"+strconv.Itoa(len(values)))
+	}
+
+	fields = append(fields, "updated_at = CURRENT_TIMESTAMP")
+	values = append(values, id)
+	query := "UPDATE employee SET " + strings.Join(fields, ", ") + " WHERE id = $" + strconv.Itoa(len(values)) +
+		" RETURNING " + employeeColumns
+
+	return scanEmployee(r.db.QueryRow(ctx, query, values...))
+}
+
+func (r *PostgresEmployeeRepository) SetActive(ctx context.Context, id string, active bool) (*Employee, error) {
+	var employee *Employee
+	err := inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		var err error
+		employee, err = scanEmployee(tx.QueryRow(ctx, `
+			UPDATE employee SET is_active = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2
+			RETURNING `+employeeColumns, active, id))
+		if err != nil || active {
+			return err
+		}
+
+		// Отключенный сотрудник теряет все выданные токены
+		_, err = tx.Exec(ctx, "DELETE FROM sessions WHERE user_id = $1", id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
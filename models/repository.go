@@ -0,0 +1,170 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// ErrNotFound возвращается репозиториями, если запись не найдена
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict возвращается, если операция противоречит текущему состоянию данных
+var ErrConflict = errors.New("conflict")
+
+// ErrForbidden возвращается, если у пользователя нет прав на операцию
+var ErrForbidden = errors.New("forbidden")
+
+// DB - общий интерфейс соединения с базой данных, используемый репозиториями
+type DB interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// TenderFilter задает условия выборки тендеров
+type TenderFilter struct {
+	ViewerID     string   // пользователь, для которого применяются правила видимости; пусто - аноним
+	CreatorID    string   // только тендеры указанного создателя
+	ServiceTypes []string // допустимые виды услуг; пусто - без фильтра
+	Limit        int
+	Offset       int
+}
+
+// TenderUpdate содержит изменяемые поля тендера; nil - поле не меняется
+type TenderUpdate struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	ServiceType *string `json:"serviceType"`
+	// Срок подачи предложений и время публикации не входят в версии тендера
+	SubmissionDeadline *time.Time `json:"submissionDeadline"`
+	PublishAt          *time.Time `json:"publishAt"`
+	// Бюджет и валюта также не входят в версии тендера
+	Budget   *Amount `json:"budget"`
+	Currency *string `json:"currency"`
+	// Параметры аукциона; в аукционе со ставками не меняются вместе со сроком и бюджетом
+	AuctionStep             *Amount `json:"auctionStep"`
+	AuctionExtensionMinutes *int    `json:"auctionExtensionMinutes"`
+}
+
+// EmployeeUpdate содержит изменяемые поля профиля сотрудника; nil - поле не меняется
+type EmployeeUpdate struct {
+	FirstName *string `json:"firstName"`
+	LastName  *string `json:"lastName"`
+}
+
+// OrganizationUpdate содержит изменяемые поля организации; nil - поле не меняется
+type OrganizationUpdate struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Type        *string `json:"type"`
+}
+
+// BidUpdate содержит изменяемые поля предложения; nil - поле не меняется
+type BidUpdate struct {
+	Name          *string `json:"name"`
+	Description   *string `json:"description"`
+	Price         *Amount `json:"price"`
+	Currency      *string `json:"currency"`
+	DeliveryTerms *string `json:"deliveryTerms"`
+}
+
+// EmployeeRepository предоставляет доступ к сотрудникам
+type EmployeeRepository interface {
+	GetByUsername(ctx context.Context, username string) (*Employee, error)
+	GetByID(ctx context.Context, id string) (*Employee, error)
+	// GetPasswordHash возвращает хеш пароля сотрудника или ErrNotFound,
+	// если пароль не задан
+	GetPasswordHash(ctx context.Context, id string) (string, error)
+	// Create регистрирует сотрудника. Возвращает ErrConflict, если имя пользователя занято
+	Create(ctx context.Context, employee *Employee, passwordHash string) error
+	Update(ctx context.Context, id string, update EmployeeUpdate) (*Employee, error)
+	// SetActive включает или отключает сотрудника. При отключении его сессии завершаются
+	SetActive(ctx context.Context, id string, active bool) (*Employee, error)
+}
+
+// SessionRepository хранит сессии, выданные при входе. Токены хранятся
+// только в виде хеша.
+type SessionRepository interface {
+	// Create создает сессию со сроком жизни ttl, отсчитанным по времени базы данных,
+	// и возвращает момент ее истечения
+	Create(ctx context.Context, tokenHash, userID string, ttl time.Duration) (time.Time, error)
+	// GetUser возвращает владельца действующей сессии или ErrNotFound
+	GetUser(ctx context.Context, tokenHash string) (*Employee, error)
+	Delete(ctx context.Context, tokenHash string) error
+}
+
+// OrganizationRepository предоставляет доступ к организациям и их ответственным
+type OrganizationRepository interface {
+	List(ctx context.Context, limit, offset int) ([]Organization, error)
+	GetByID(ctx context.Context, id string) (*Organization, error)
+	// Create создает организацию и назначает создателя ответственным за нее
+	Create(ctx context.Context, organization *Organization, creatorID string) error
+	Update(ctx context.Context, id string, update OrganizationUpdate) (*Organization, error)
+	// Delete удаляет организацию. Возвращает ErrConflict, если у организации есть тендеры
+	Delete(ctx context.Context, id string) error
+	ListResponsibles(ctx context.Context, id string) ([]Employee, error)
+	// AddResponsible назначает сотрудника ответственным; повторное назначение не является ошибкой
+	AddResponsible(ctx context.Context, id, userID string) error
+	// RemoveResponsible снимает сотрудника с ответственных или возвращает ErrNotFound
+	RemoveResponsible(ctx context.Context, id, userID string) error
+	// ListResponsibilities возвращает организации, за которые отвечает пользователь:
+	// идентификатор организации -> идентификатор записи organization_responsible
+	ListResponsibilities(ctx context.Context, userID string) (map[string]string, error)
+}
+
+// TenderRepository предоставляет доступ к тендерам и их версиям
+type TenderRepository interface {
+	List(ctx context.Context, filter TenderFilter) ([]Tender, error)
+	GetByID(ctx context.Context, id string) (*Tender, error)
+	// IsVisible проверяет, что тендер существует и виден пользователю
+	IsVisible(ctx context.Context, id, userID string) (bool, error)
+	Create(ctx context.Context, tender *Tender, responsibleID string) error
+	// PublishScheduled публикует тендеры, время публикации которых наступило.
+	// Возвращает идентификаторы опубликованных тендеров.
+	PublishScheduled(ctx context.Context) ([]string, error)
+	// CloseExpired закрывает опубликованные тендеры с истекшим сроком подачи предложений.
+	// Опубликованные предложения таких тендеров остаются на рассмотрении.
+	// Возвращает идентификаторы закрытых тендеров.
+	CloseExpired(ctx context.Context) ([]string, error)
+	// ChangeStatus переводит тендер в новый статус по правилам конечного автомата
+	// и записывает переход в историю. Возвращает ErrInvalidTransition,
+	// ErrReasonRequired или ErrTenderAwarded, если переход невозможен.
+	ChangeStatus(ctx context.Context, id, status, reason, changedBy string) (*Tender, error)
+	StatusHistory(ctx context.Context, id string) ([]TenderStatusChange, error)
+	// AuctionState возвращает текущее состояние аукциона. Возвращает ErrNotFound,
+	// если тендер не найден, и ErrNotAuction, если это не аукцион.
+	AuctionState(ctx context.Context, id string) (*AuctionState, error)
+	// Edit изменяет тендер, сохраняя предыдущую версию в истории. Возвращает
+	// ErrBidsOutsideBudget, если действующие предложения не укладываются в новый бюджет,
+	// и ErrAuctionHasBids при изменении условий аукциона, в котором уже есть ставки.
+	Edit(ctx context.Context, id string, update TenderUpdate, changedBy string) (*Tender, error)
+	// Rollback восстанавливает сохраненную версию как новую версию тендера
+	Rollback(ctx context.Context, id string, version int, changedBy string) (*Tender, error)
+	Versions(ctx context.Context, id string) ([]TenderVersion, error)
+}
+
+// BidRepository предоставляет доступ к предложениям, решениям и отзывам
+type BidRepository interface {
+	Create(ctx context.Context, bid *Bid) error
+	// GetByID возвращает предложение вместе с организациями автора-пользователя
+	GetByID(ctx context.Context, id string) (*Bid, error)
+	ListByAuthor(ctx context.Context, authorID string, limit, offset int) ([]Bid, error)
+	// ListByTender возвращает предложения тендера в порядке sortBy (BidSort*)
+	ListByTender(ctx context.Context, tenderID, sortBy string, limit, offset int) ([]Bid, error)
+	UpdateStatus(ctx context.Context, id, status string) (*Bid, error)
+	// Edit изменяет предложение, сохраняя предыдущую версию в истории
+	Edit(ctx context.Context, id string, update BidUpdate, changedBy string) (*Bid, error)
+	// Rollback восстанавливает сохраненную версию как новую версию предложения
+	Rollback(ctx context.Context, id string, version int, changedBy string) (*Bid, error)
+	// SubmitDecision сохраняет решение ответственного и применяет итог голосования.
+	// Возвращает ErrForbidden, если пользователь не отвечает за организацию тендера.
+	SubmitDecision(ctx context.Context, id, userID, decision string) (*Bid, DecisionTally, error)
+	AddFeedback(ctx context.Context, id, userID, feedback string) error
+	HasAuthorBidForTender(ctx context.Context, tenderID, authorID string) (bool, error)
+	ListReviewsByAuthor(ctx context.Context, authorID string, limit, offset int) ([]BidReview, error)
+}
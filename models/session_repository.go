@@ -0,0 +1,39 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// PostgresSessionRepository - реализация SessionRepository для PostgreSQL
+type PostgresSessionRepository struct {
+	db DB
+}
+
+// NewSessionRepository создает репозиторий сессий
+func NewSessionRepository(db DB) *PostgresSessionRepository {
+	return &PostgresSessionRepository{db: db}
+}
+
+func (r *PostgresSessionRepository) Create(ctx context.Context, tokenHash, userID string, ttl time.Duration) (time.Time, error) {
+	// Срок вычисляется в базе: с ним же сравнивается CURRENT_TIMESTAMP в GetUser
+	var expiresAt time.Time
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO sessions (token_hash, user_id, expires_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP + $3::interval)
+		RETURNING expires_at`, tokenHash, userID, ttl).Scan(&expiresAt)
+	return expiresAt, err
+}
+
+func (r *PostgresSessionRepository) GetUser(ctx context.Context, tokenHash string) (*Employee, error) {
+	return scanEmployee(r.db.QueryRow(ctx, `
+		SELECT e.id, e.username, e.first_name, e.last_name, e.is_admin, e.is_active, e.created_at, e.updated_at
+		FROM sessions s
+		INNER JOIN employee e ON e.id = s.user_id
+		WHERE s.token_hash = $1 AND s.expires_at > CURRENT_TIMESTAMP`, tokenHash))
+}
+
+func (r *PostgresSessionRepository) Delete(ctx context.Context, tokenHash string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM sessions WHERE token_hash = $1", tokenHash)
+	return err
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	// ErrInvalidTransition возвращается, если переход между статусами тендера запрещен
+	ErrInvalidTransition = errors.New("invalid tender status transition")
+	// ErrReasonRequired возвращается, если для перехода не указана причина
+	ErrReasonRequired = errors.New("reason is required for this transition")
+	// ErrTenderAwarded возвращается при попытке открыть тендер с согласованным предложением
+	ErrTenderAwarded = errors.New("tender has an approved bid")
+	// ErrTenderNotPublished возвращается при работе с предложениями по неопубликованному тендеру
+	ErrTenderNotPublished = errors.New("tender is not published")
+	// ErrBidDecided возвращается при попытке изменить предложение, по которому принято решение
+	ErrBidDecided = errors.New("bid already has a decision")
+	// ErrBidCanceled возвращается при попытке изменить отмененное предложение или принять по нему решение
+	ErrBidCanceled = errors.New("bid is canceled")
+	// ErrBidNotPublished возвращается при попытке принять решение по неопубликованному предложению
+	ErrBidNotPublished = errors.New("bid is not published")
+	// ErrDeadlinePassed возвращается, если срок подачи предложений по тендеру истек
+	ErrDeadlinePassed = errors.New("submission deadline has passed")
+	// ErrCurrencyMismatch возвращается, если валюта предложения отличается от валюты бюджета тендера
+	ErrCurrencyMismatch = errors.New("bid currency does not match tender budget currency")
+	// ErrBudgetExceeded возвращается, если цена предложения превышает бюджет тендера
+	ErrBudgetExceeded = errors.New("bid price exceeds tender budget")
+	// ErrBidsOutsideBudget возвращается при изменении бюджета, в который не укладываются поданные предложения
+	ErrBidsOutsideBudget = errors.New("existing bids do not fit the tender budget")
+)
+
+// tenderTransition описывает допустимый переход между статусами тендера
+type tenderTransition struct {
+	reasonRequired bool
+}
+
+// tenderTransitions - конечный автомат статусов тендера:
+// CREATED -> PUBLISHED (публикация), CREATED/PUBLISHED -> CLOSED (закрытие),
+// CLOSED -> PUBLISHED (повторное открытие). Закрытие и повторное открытие требуют причины.
+var tenderTransitions = map[string]map[string]tenderTransition{
+	TenderStatusCreated: {
+		TenderStatusPublished: {},
+		TenderStatusClosed:    {reasonRequired: true},
+	},
+	TenderStatusPublished: {
+		TenderStatusClosed: {reasonRequired: true},
+	},
+	TenderStatusClosed: {
+		TenderStatusPublished: {reasonRequired: true},
+	},
+}
+
+// CheckTenderTransition проверяет, что переход тендера из статуса from в статус to
+// разрешен и для него указана причина, если она обязательна
+func CheckTenderTransition(from, to, reason string) error {
+	transition, ok := tenderTransitions[from][to]
+	if !ok {
+		return ErrInvalidTransition
+	}
+	if transition.reasonRequired && reason == "" {
+		return ErrReasonRequired
+	}
+	return nil
+}
+
+// transitionTender переводит тендер в новый статус в рамках транзакции и записывает
+// переход в tender_status_history. changedBy - пустая строка для системных переходов.
+func transitionTender(ctx context.Context, tx pgx.Tx, id, to, reason, changedBy string) (*Tender, error) {
+	var from string
+	var expired bool
+	err := tx.QueryRow(ctx, `
+		SELECT status, COALESCE(submission_deadline <= CURRENT_TIMESTAMP, FALSE)
+		FROM tender WHERE id = $1 FOR UPDATE`, id).Scan(&from, &expired)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	if err = CheckTenderTransition(from, to, reason); err != nil {
+		return nil, err
+	}
+
+	// Тендер с истекшим сроком подачи предложений опубликовать нельзя
+	if to == TenderStatusPublished && expired {
+		return nil, ErrDeadlinePassed
+	}
+
+	// Тендер с согласованным предложением нельзя открыть повторно
+	if from == TenderStatusClosed {
+		var awarded bool
+		err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM bids WHERE tender_id = $1 AND decision = 'Approved')", id).Scan(&awarded)
+		if err != nil {
+			return nil, err
+		}
+		if awarded {
+			return nil, ErrTenderAwarded
+		}
+	}
+
+	tender, err := scanTender(tx.QueryRow(ctx, `
+		UPDATE tender SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2
+		RETURNING `+tenderColumns, to, id))
+	if err != nil {
+		return nil, err
+	}
+
+	// При закрытии тендера предложения без решения отменяются. После истечения срока
+	// отменяются только черновики: опубликованные вовремя предложения остаются
+	// на рассмотрении ответственных.
+	if to == TenderStatusClosed {
+		_, err = tx.Exec(ctx, `
+			UPDATE bids SET status = 'CANCELED'
+			WHERE tender_id = $1 AND decision IS NULL AND status <> 'CANCELED'
+				AND (NOT $2 OR status <> 'PUBLISHED')`, id, expired)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO tender_status_history (tender_id, from_status, to_status, reason, changed_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, '')::uuid)`,
+		id, from, to, reason, changedBy)
+	if err != nil {
+		return nil, err
+	}
+	return tender, nil
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+)
+
+func TestCheckTenderTransition(t *testing.T) {
+	tests := []struct {
+		from, to, reason string
+		want             error
+	}{
+		// Разрешенные переходы
+		{TenderStatusCreated, TenderStatusPublished, "", nil},
+		{TenderStatusCreated, TenderStatusClosed, "duplicate", nil},
+		{TenderStatusPublished, TenderStatusClosed, "winner chosen", nil},
+		{TenderStatusClosed, TenderStatusPublished, "reopened by mistake", nil},
+
+		// Переходы без обязательной причины
+		{TenderStatusCreated, TenderStatusClosed, "", ErrReasonRequired},
+		{TenderStatusPublished, TenderStatusClosed, "", ErrReasonRequired},
+		{TenderStatusClosed, TenderStatusPublished, "", ErrReasonRequired},
+
+		// Запрещенные переходы
+		{TenderStatusPublished, TenderStatusCreated, "", ErrInvalidTransition},
+		{TenderStatusClosed, TenderStatusCreated, "reason", ErrInvalidTransition},
+		{TenderStatusCreated, TenderStatusCreated, "", ErrInvalidTransition},
+		{TenderStatusPublished, TenderStatusPublished, "", ErrInvalidTransition},
+		{TenderStatusClosed, TenderStatusClosed, "reason", ErrInvalidTransition},
+		{"ARCHIVED", TenderStatusPublished, "reason", ErrInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s->%s", tt.from, tt.to), func(t *testing.T) {
+			if err := CheckTenderTransition(tt.from, tt.to, tt.reason); !errors.Is(err, tt.want) {
+				t.Errorf("CheckTenderTransition(%q, %q, %q) = %v, want %v", tt.from, tt.to, tt.reason, err, tt.want)
+			}
+		})
+	}
+}
+
+// fakeRow возвращает заранее заданные значения столбцов
+type fakeRow []interface{}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if len(dest) != len(r) {
+		return fmt.Errorf("scan: %d destinations for %d columns", len(dest), len(r))
+	}
+	for i, value := range r {
+		switch d := dest[i].(type) {
+		case *string:
+			*d = value.(string)
+		case *bool:
+			*d = value.(bool)
+		case **string:
+			// nil - NULL в столбце
+			if value == nil {
+				*d = nil
+			} else {
+				v := value.(string)
+				*d = &v
+			}
+		default:
+			return fmt.Errorf("scan: unsupported destination %T", dest[i])
+		}
+	}
+	return nil
+}
+
+// fakeTx отвечает на QueryRow строками rows по порядку. Остальные методы
+// pgx.Tx не реализованы: их вызов означает, что транзакция не была прервана.
+type fakeTx struct {
+	pgx.Tx
+	rows []fakeRow
+}
+
+func (tx *fakeTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if len(tx.rows) == 0 {
+		panic("unexpected query: " + sql)
+	}
+	row := tx.rows[0]
+	tx.rows = tx.rows[1:]
+	return row
+}
+
+func TestTransitionTenderRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		to     string
+		reason string
+		rows   []fakeRow
+		want   error
+	}{
+		{
+			name:   "reopen awarded tender",
+			to:     TenderStatusPublished,
+			reason: "reopen",
+			rows:   []fakeRow{{TenderStatusClosed, false}, {true}},
+			want:   ErrTenderAwarded,
+		},
+		{
+			name: "publish after deadline",
+			to:   TenderStatusPublished,
+			rows: []fakeRow{{TenderStatusCreated, true}},
+			want: ErrDeadlinePassed,
+		},
+		{
+			name:   "reopen after deadline",
+			to:     TenderStatusPublished,
+			reason: "reopen",
+			rows:   []fakeRow{{TenderStatusClosed, true}},
+			want:   ErrDeadlinePassed,
+		},
+		{
+			name: "close without reason",
+			to:   TenderStatusClosed,
+			rows: []fakeRow{{TenderStatusPublished, false}},
+			want: ErrReasonRequired,
+		},
+		{
+			name:   "back to created",
+			to:     TenderStatusCreated,
+			reason: "undo",
+			rows:   []fakeRow{{TenderStatusPublished, false}},
+			want:   ErrInvalidTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &fakeTx{rows: tt.rows}
+
+			tender, err := transitionTender(context.Background(), tx, "tender-1", tt.to, tt.reason, "user-1")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("transitionTender() error = %v, want %v", err, tt.want)
+			}
+			if tender != nil {
+				t.Errorf("transitionTender() returned tender %v on error", tender)
+			}
+			if len(tx.rows) != 0 {
+				t.Errorf("%d expected queries were not executed", len(tx.rows))
+			}
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package models
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresTenderRepository - реализация TenderRepository для PostgreSQL
+type PostgresTenderRepository struct {
+	db DB
+}
+
+// NewTenderRepository создает репозиторий тендеров
+func NewTenderRepository(db DB) *PostgresTenderRepository {
+	return &PostgresTenderRepository{db: db}
+}
+
+const tenderColumns = "id, name, description, service_type, status, organization_id, creator_id, version, created_at, submission_deadline, publish_at, budget, currency, " +
+	"tender_type, auction_step, auction_extension_minutes"
+
+func scanTender(row pgx.Row) (*Tender, error) {
+	var t Tender
+	err := row.Scan(&t.ID, &t.Name, &t.Description, &t.ServiceType, &t.Status, &t.OrganizationID, &t.CreatorID, &t.Version, &t.CreatedAt,
+		&t.SubmissionDeadline, &t.PublishAt, &t.Budget, &t.Currency, &t.Type, &t.AuctionStep, &t.AuctionExtensionMinutes)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &t, nil
+}
+
+// tenderVisibleCondition возвращает SQL-условие видимости тендера для пользователя:
+// опубликованные тендеры видны всем, остальные - только ответственным за организацию.
+// userParam - плейсхолдер с идентификатором пользователя, например "$1".
+func tenderVisibleCondition(userParam string) string {
+	return `(status = 'PUBLISHED' OR organization_id IN (
+		SELECT organization_id FROM organization_responsible WHERE user_id = ` + userParam + `))`
+}
+
+func (r *PostgresTenderRepository) List(ctx context.Context, filter TenderFilter) ([]Tender, error) {
+	var conditions []string
+	var values []interface{}
+
+	// Анонимный пользователь видит только опубликованные тендеры,
+	// ответственный - также тендеры своей организации в любом статусе
+	if filter.CreatorID != "" {
+		values = append(values, filter.CreatorID)
+		conditions = append(conditions, "creator_id = $"+strconv.Itoa(len(values)))
+	} else if filter.ViewerID == "" {
+		conditions = append(conditions, "status = 'PUBLISHED'")
+	} else {
+		values = append(values, filter.ViewerID)
+		conditions = append(conditions, tenderVisibleCondition("$"+strconv.Itoa(len(values))))
+	}
+
+	// Фильтр по service_type (допускается несколько значений)
+	if len(filter.ServiceTypes) > 0 {
+		values = append(values, filter.ServiceTypes)
+		conditions = append(conditions, "service_type = ANY($"+strconv.Itoa(len(values))+")")
+	}
+
+	values = append(values, filter.Limit, filter.Offset)
+	query := "SELECT " + tenderColumns + " FROM tender WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY name ASC, id ASC LIMIT $" + strconv.Itoa(len(values)-1) + " OFFSET $" + strconv.Itoa(len(values))
+
+	rows, err := r.db.Query(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenders := []Tender{}
+	for rows.Next() {
+		tender, err := scanTender(rows)
+		if err != nil {
+			return nil, err
+		}
+		tenders = append(tenders, *tender)
+	}
+	return tenders, rows.Err()
+}
+
+func (r *PostgresTenderRepository) GetByID(ctx context.Context, id string) (*Tender, error) {
+	return scanTender(r.db.QueryRow(ctx, "SELECT "+tenderColumns+" FROM tender WHERE id = $1", id))
+}
+
+func (r *PostgresTenderRepository) IsVisible(ctx context.Context, id, userID string) (bool, error) {
+	var visible bool
+	err := r.db.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM tender WHERE id = $1 AND "+tenderVisibleCondition("$2")+")", id, userID).Scan(&visible)
+	return visible, err
+}
+
+func (r *PostgresTenderRepository) Create(ctx context.Context, tender *Tender, responsibleID string) error {
+	tender.Status = TenderStatusCreated
+	tender.Version = 1
+	if tender.Type == "" {
+		tender.Type = TenderTypeStandard
+	}
+	return r.db.QueryRow(ctx, `
+		INSERT INTO tender (name, description, service_type, organization_id, creator_id, responsible_id, status, version,
+			submission_deadline, publish_at, budget, currency, tender_type, auction_step, auction_extension_minutes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, CURRENT_TIMESTAMP) RETURNING id, created_at`,
+		tender.Name, tender.Description, tender.ServiceType, tender.OrganizationID, tender.CreatorID, responsibleID, tender.Status, tender.Version,
+		tender.SubmissionDeadline, tender.PublishAt, tender.Budget, tender.Currency, tender.Type, tender.AuctionStep, tender.AuctionExtensionMinutes).
+		Scan(&tender.ID, &tender.CreatedAt)
+}
+
+func (r *PostgresTenderRepository) ChangeStatus(ctx context.Context, id, status, reason, changedBy string) (*Tender, error) {
+	var tender *Tender
+	err := inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		var err error
+		tender, err = transitionTender(ctx, tx, id, status, reason, changedBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tender, nil
+}
+
+func (r *PostgresTenderRepository) StatusHistory(ctx context.Context, id string) ([]TenderStatusChange, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT h.from_status, h.to_status, h.reason, e.username, h.changed_at
+		FROM tender_status_history h
+		LEFT JOIN employee e ON e.id = h.changed_by
+		WHERE h.tender_id = $1
+		ORDER BY h.changed_at ASC, h.id ASC`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []TenderStatusChange{}
+	for rows.Next() {
+		var change TenderStatusChange
+		err = rows.Scan(&change.From, &change.To, &change.Reason, &change.ChangedBy, &change.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
+
+func (r *PostgresTenderRepository) PublishScheduled(ctx context.Context) ([]string, error) {
+	return r.transitionDue(ctx, TenderStatusPublished, "", `
+		status = 'CREATED' AND publish_at <= CURRENT_TIMESTAMP
+		AND (submission_deadline IS NULL OR submission_deadline > CURRENT_TIMESTAMP)`)
+}
+
+func (r *PostgresTenderRepository) CloseExpired(ctx context.Context) ([]string, error) {
+	// Тендер закрывается в срок; опубликованные вовремя предложения
+	// остаются на рассмотрении ответственных, см. transitionTender
+	return r.transitionDue(ctx, TenderStatusClosed, "Submission deadline passed",
+		"status = 'PUBLISHED' AND submission_deadline <= CURRENT_TIMESTAMP")
+}
+
+// transitionDue переводит в статус to все тендеры, удовлетворяющие условию condition.
+// Каждый тендер переводится в отдельной транзакции, условие перепроверяется
+// под блокировкой: тендер мог быть изменен после выборки.
+func (r *PostgresTenderRepository) transitionDue(ctx context.Context, to, reason, condition string) ([]string, error) {
+	rows, err := r.db.Query(ctx, "SELECT id FROM tender WHERE "+condition)
+	if err != nil {
+		return nil, err
+	}
+	var due []string
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		due = append(due, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, id := range due {
+		applied := false
+		err = inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+			var stillDue bool
+			err := tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM tender WHERE id = $1 AND "+condition+")", id).Scan(&stillDue)
+			if err != nil || !stillDue {
+				return err
+			}
+			_, err = transitionTender(ctx, tx, id, to, reason, "")
+			applied = err == nil
+			return err
+		})
+		if err != nil {
+			return changed, err
+		}
+		if applied {
+			changed = append(changed, id)
+		}
+	}
+	return changed, nil
+}
+
+// lockTender блокирует строку тендера до конца транзакции
+func lockTender(ctx context.Context, tx pgx.Tx, id string) error {
+	var lockedID string
+	err := tx.QueryRow(ctx, "SELECT id FROM tender WHERE id = $1 FOR UPDATE", id).Scan(&lockedID)
+	return notFound(err)
+}
+
+// saveTenderVersion сохраняет текущее состояние тендера в tender_versions
+// в рамках переданной транзакции
+func saveTenderVersion(ctx context.Context, tx pgx.Tx, tenderID, userID string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO tender_versions (tender_id, version, name, description, service_type, changed_by)
+		SELECT id, version, name, description, service_type, $2 FROM tender WHERE id = $1
+		ON CONFLICT (tender_id, version) DO NOTHING`, tenderID, userID)
+	return err
+}
+
+func (r *PostgresTenderRepository) Edit(ctx context.Context, id string, update TenderUpdate, changedBy string) (*Tender, error) {
+	// Построение запроса на обновление
+	var fields []string
+	var values []interface{}
+
+	if update.Name != nil {
+		values = append(values, *update.Name)
+		fields = append(fields, "name = $"+strconv.Itoa(len(values)))
+	}
+	if update.Description != nil {
+		values = append(values, *update.Description)
+		fields = append(fields, "description = $"+strconv.Itoa(len(values)))
+	}
+	if update.ServiceType != nil {
+		values = append(values, *update.ServiceType)
+		fields = append(fields, "service_type = $"+strconv.Itoa(len(values)))
+	}
+	if update.SubmissionDeadline != nil {
+		values = append(values, *update.SubmissionDeadline)
+		fields = append(fields, "submission_deadline = $"+strconv.Itoa(len(values)))
+	}
+	if update.PublishAt != nil {
+		values = append(values, *update.PublishAt)
+		fields = append(fields, "publish_at = $"+strconv.Itoa(len(values)))
+	}
+	if update.Budget != nil {
+		values = append(values, *update.Budget)
+		fields = append(fields, "budget = $"+strconv.Itoa(len(values)))
+	}
+	if update.Currency != nil {
+		values = append(values, *update.Currency)
+		fields = append(fields, "currency = $"+strconv.Itoa(len(values)))
+	}
+	if update.AuctionStep != nil {
+		values = append(values, *update.AuctionStep)
+		fields = append(fields, "auction_step = $"+strconv.Itoa(len(values)))
+	}
+	if update.AuctionExtensionMinutes != nil {
+		values = append(values, *update.AuctionExtensionMinutes)
+		fields = append(fields, "auction_extension_minutes = $"+strconv.Itoa(len(values)))
+	}
+
+	// Инкремент версии и добавление в запрос
+	fields = append(fields, "version = version + 1, updated_at = CURRENT_TIMESTAMP")
+	values = append(values, id)
+	query := "UPDATE tender SET " + strings.Join(fields, ", ") + " WHERE id = $" + strconv.Itoa(len(values)) +
+		" RETURNING " + tenderColumns
+
+	var tender *Tender
+	err := inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		if err := lockTender(ctx, tx, id); err != nil {
+			return err
+		}
+
+		// Условия идущего аукциона не меняются после первой ставки
+		if update.changesAuctionTerms() {
+			if err := checkAuctionTermsEditable(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+
+		// Сохранение текущей версии тендера перед изменением
+		if err := saveTenderVersion(ctx, tx, id, changedBy); err != nil {
+			return err
+		}
+
+		var err error
+		tender, err = scanTender(tx.QueryRow(ctx, query, values...))
+		if err != nil {
+			return err
+		}
+
+		// Новый бюджет должен вмещать уже поданные предложения
+		if update.Budget != nil || update.Currency != nil {
+			return checkTenderBidsBudget(ctx, tx, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tender, nil
+}
+
+// checkTenderBidsBudget проверяет, что все действующие предложения тендера
+// (не отмененные и не отклоненные) укладываются в его бюджет и валюту
+func checkTenderBidsBudget(ctx context.Context, tx pgx.Tx, tenderID string) error {
+	var outside bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM bids b
+			JOIN tender t ON t.id = b.tender_id
+			WHERE b.tender_id = $1 AND b.status <> 'CANCELED' AND b.decision IS DISTINCT FROM 'Rejected'
+				AND (b.currency <> t.currency OR b.price > t.budget)
+		)`, tenderID).Scan(&outside)
+	if err != nil {
+		return err
+	}
+	if outside {
+		return ErrBidsOutsideBudget
+	}
+	return nil
+}
+
+func (r *PostgresTenderRepository) Rollback(ctx context.Context, id string, version int, changedBy string) (*Tender, error) {
+	var tender *Tender
+	err := inSerializableTx(ctx, r.db, func(tx pgx.Tx) error {
+		if err := lockTender(ctx, tx, id); err != nil {
+			return err
+		}
+
+		// Проверка существования версии
+		var exists bool
+		err := tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM tender_versions WHERE tender_id = $1 AND version = $2)", id, version).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNotFound
+		}
+
+		// Сохранение текущей версии тендера перед откатом
+		if err = saveTenderVersion(ctx, tx, id, changedBy); err != nil {
+			return err
+		}
+
+		// Откат к указанной версии и инкремент версии
+		tender, err = scanTender(tx.QueryRow(ctx, `
+			UPDATE tender
+			SET name = v.name, description = v.description, service_type = v.service_type, version = tender.version + 1, updated_at = CURRENT_TIMESTAMP
+			FROM tender_versions v
+			WHERE tender.id = $1 AND v.tender_id = $1 AND v.version = $2
+			RETURNING tender.id, tender.name, tender.description, tender.service_type, tender.status,
+				tender.organization_id, tender.creator_id, tender.version, tender.created_at,
+				tender.submission_deadline, tender.publish_at, tender.budget, tender.currency,
+				tender.tender_type, tender.auction_step, tender.auction_extension_minutes`, id, version))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tender, nil
+}
+
+// tenderHistoryQuery возвращает все версии тендера: сохраненные в tender_versions
+// и текущую. Автором версии считается тот, кто заменил предыдущую версию,
+// для первой версии - создатель тендера.
+const tenderHistoryQuery = `
+	WITH history AS (
+		SELECT version, name, description, service_type, changed_by, changed_at
+		FROM tender_versions
+		WHERE tender_id = $1
+		UNION ALL
+		SELECT version, name, description, service_type, NULL, NULL
+		FROM tender
+		WHERE id = $1 AND NOT EXISTS (
+			SELECT 1 FROM tender_versions v WHERE v.tender_id = tender.id AND v.version = tender.version
+		)
+	), authored AS (
+		SELECT h.version, h.name, h.description, h.service_type,
+			CASE
+				WHEN h.version = 1 THEN t.creator_id
+				WHEN LAG(h.version) OVER w = h.version - 1 THEN LAG(h.changed_by) OVER w
+			END AS author_id,
+			CASE
+				WHEN h.version = 1 THEN t.created_at
+				WHEN LAG(h.version) OVER w = h.version - 1 THEN LAG(h.changed_at) OVER w
+			END AS authored_at
+		FROM history h
+		INNER JOIN tender t ON t.id = $1
+		WINDOW w AS (ORDER BY h.version)
+	)
+	SELECT a.version, a.name, a.description, a.service_type, e.username, a.authored_at
+	FROM authored a
+	LEFT JOIN employee e ON e.id = a.author_id
+	ORDER BY a.version ASC`
+
+func (r *PostgresTenderRepository) Versions(ctx context.Context, id string) ([]TenderVersion, error) {
+	rows, err := r.db.Query(ctx, tenderHistoryQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []TenderVersion{}
+	for rows.Next() {
+		var v TenderVersion
+		err = rows.Scan(&v.Version, &v.Name, &v.Description, &v.ServiceType, &v.ChangedBy, &v.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
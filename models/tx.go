@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// Коды SQLSTATE, при которых транзакцию можно безопасно повторить
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+)
+
+// maxTxAttempts - максимальное число попыток выполнить сериализуемую транзакцию
+const maxTxAttempts = 5
+
+// txRetryDelay - базовая задержка перед повтором, растет с каждой попыткой
+const txRetryDelay = 10 * time.Millisecond
+
+// isRetryable проверяет, что транзакция прервана конфликтом сериализации или взаимоблокировкой
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailureCode || pgErr.Code == deadlockDetectedCode
+}
+
+// inSerializableTx выполняет fn в транзакции с уровнем изоляции SERIALIZABLE.
+// При конфликте сериализации транзакция повторяется целиком, поэтому fn
+// не должна иметь побочных эффектов вне базы данных.
+func inSerializableTx(ctx context.Context, db DB, fn func(tx pgx.Tx) error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = runTx(ctx, db, fn)
+		if !isRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * txRetryDelay):
+		}
+	}
+	return err
+}
+
+func runTx(ctx context.Context, db DB, fn func(tx pgx.Tx) error) error {
+	tx, err := db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
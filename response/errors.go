@@ -0,0 +1,62 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgconn"
+)
+
+// Машиночитаемые коды ошибок, возвращаемые вместе с описанием
+const (
+	CodeInvalidRequest       = "INVALID_REQUEST"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeUserNotFound         = "USER_NOT_FOUND"
+	CodePermissionDenied     = "PERMISSION_DENIED"
+	CodeOrganizationNotFound = "ORGANIZATION_NOT_FOUND"
+	CodeTenderNotFound       = "TENDER_NOT_FOUND"
+	CodeBidNotFound          = "BID_NOT_FOUND"
+	CodeVersionNotFound      = "VERSION_NOT_FOUND"
+	CodeConflict             = "CONFLICT"
+	CodeInternalError        = "INTERNAL_ERROR"
+	CodeTimeout              = "TIMEOUT"
+	CodeUnavailable          = "SERVICE_UNAVAILABLE"
+)
+
+// queryCanceledCode - SQLSTATE отмененного сервером запроса (statement_timeout)
+const queryCanceledCode = "57014"
+
+// ErrorResponse соответствует схеме errorResponse из спецификации
+type ErrorResponse struct {
+	Reason string `json:"reason"`
+	Code   string `json:"code"`
+}
+
+// Error отправляет ошибку в формате JSON с указанным статусом
+func Error(w http.ResponseWriter, status int, code, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Reason: reason,
+		Code:   code,
+	})
+}
+
+// DBError отправляет ошибку обращения к базе данных. Истекший срок запроса
+// возвращается как 504, отмененный запрос - как 503, остальные ошибки - как 500
+// с указанным описанием.
+func DBError(w http.ResponseWriter, err error, reason string) {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &pgErr) && pgErr.Code == queryCanceledCode:
+		Error(w, http.StatusGatewayTimeout, CodeTimeout, "Request timed out")
+	case errors.Is(err, context.Canceled):
+		Error(w, http.StatusServiceUnavailable, CodeUnavailable, "Request canceled")
+	default:
+		Error(w, http.StatusInternalServerError, CodeInternalError, reason)
+	}
+}
@@ -2,23 +2,56 @@ package routes
 
 import (
 	"avito-project/handlers"
+	"avito-project/validation"
 
 	"github.com/gorilla/mux"
 )
 
 func SetupRoutes(router *mux.Router) {
+	router.Use(validation.Middleware)
+	router.Use(handlers.AuthMiddleware)
+
 	router.HandleFunc("/api/ping", handlers.PingHandler).Methods("GET")
+	router.HandleFunc("/api/auth/login", handlers.LoginHandler).Methods("POST")
+	router.HandleFunc("/api/auth/logout", handlers.LogoutHandler).Methods("POST")
+
 	router.HandleFunc("/api/tenders", handlers.GetTendersHandler).Methods("GET")
 	router.HandleFunc("/api/tenders/new", handlers.CreateTenderHandler).Methods("POST")
 	router.HandleFunc("/api/tenders/my", handlers.GetMyTendersHandler).Methods("GET")
 	router.HandleFunc("/api/tenders/{tenderId}/status", handlers.GetTenderStatusHandler).Methods("GET")
 	router.HandleFunc("/api/tenders/{tenderId}/status", handlers.UpdateTenderStatusHandler).Methods("PUT")
+	router.HandleFunc("/api/tenders/{tenderId}/status_history", handlers.GetTenderStatusHistoryHandler).Methods("GET")
+	router.HandleFunc("/api/tenders/{tenderId}/auction", handlers.GetTenderAuctionHandler).Methods("GET")
 	router.HandleFunc("/api/tenders/{tenderId}/edit", handlers.EditTenderHandler).Methods("PATCH")
 	router.HandleFunc("/api/tenders/{tenderId}/rollback/{version}", handlers.RollbackTenderHandler).Methods("PUT")
+	router.HandleFunc("/api/tenders/{tenderId}/versions", handlers.GetTenderVersionsHandler).Methods("GET")
+	router.HandleFunc("/api/tenders/{tenderId}/versions/{a}/diff/{b}", handlers.DiffTenderVersionsHandler).Methods("GET")
+
+	router.HandleFunc("/api/employees", handlers.GetEmployeeByUsernameHandler).Methods("GET")
+	router.HandleFunc("/api/employees/new", handlers.RegisterEmployeeHandler).Methods("POST")
+	router.HandleFunc("/api/employees/{employeeId}", handlers.GetEmployeeHandler).Methods("GET")
+	router.HandleFunc("/api/employees/{employeeId}/edit", handlers.EditEmployeeHandler).Methods("PATCH")
+	router.HandleFunc("/api/employees/{employeeId}/deactivate", handlers.DeactivateEmployeeHandler).Methods("PUT")
+	router.HandleFunc("/api/employees/{employeeId}/activate", handlers.ActivateEmployeeHandler).Methods("PUT")
+
+	router.HandleFunc("/api/organizations", handlers.GetOrganizationsHandler).Methods("GET")
+	router.HandleFunc("/api/organizations/new", handlers.CreateOrganizationHandler).Methods("POST")
+	router.HandleFunc("/api/organizations/{organizationId}", handlers.GetOrganizationHandler).Methods("GET")
+	router.HandleFunc("/api/organizations/{organizationId}", handlers.DeleteOrganizationHandler).Methods("DELETE")
+	router.HandleFunc("/api/organizations/{organizationId}/edit", handlers.EditOrganizationHandler).Methods("PATCH")
+	router.HandleFunc("/api/organizations/{organizationId}/responsibles", handlers.GetOrganizationResponsiblesHandler).Methods("GET")
+	router.HandleFunc("/api/organizations/{organizationId}/responsibles", handlers.AddOrganizationResponsibleHandler).Methods("POST")
+	router.HandleFunc("/api/organizations/{organizationId}/responsibles/{userId}", handlers.RemoveOrganizationResponsibleHandler).Methods("DELETE")
 
 	router.HandleFunc("/api/bids/new", handlers.CreateBidHandler).Methods("POST")
 	router.HandleFunc("/api/bids/my", handlers.GetUserBidsHandler).Methods("GET")
 	router.HandleFunc("/api/bids/{tenderId}/list", handlers.GetBidsForTenderHandler).Methods("GET")
+	router.HandleFunc("/api/bids/{bidId}/status", handlers.GetBidStatusHandler).Methods("GET")
+	router.HandleFunc("/api/bids/{bidId}/status", handlers.UpdateBidStatusHandler).Methods("PUT")
+	router.HandleFunc("/api/bids/{bidId}/edit", handlers.EditBidHandler).Methods("PATCH")
+	router.HandleFunc("/api/bids/{bidId}/rollback/{version}", handlers.RollbackBidHandler).Methods("PUT")
 	router.HandleFunc("/api/bids/{bidId}/submit_decision", handlers.SubmitBidDecisionHandler).Methods("PUT")
+	router.HandleFunc("/api/bids/{bidId}/feedback", handlers.SubmitBidFeedbackHandler).Methods("PUT")
+	router.HandleFunc("/api/bids/{tenderId}/reviews", handlers.GetBidReviewsHandler).Methods("GET")
 
 }
@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"avito-project/validation"
+)
+
+// TestRoutesHaveOperations проверяет, что каждый маршрут описан в validation.Operations:
+// иначе запросы к нему проходили бы без проверки параметров
+func TestRoutesHaveOperations(t *testing.T) {
+	router := mux.NewRouter()
+	SetupRoutes(router)
+
+	registered := make(map[string]bool)
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			key := method + " " + template
+			registered[key] = true
+			if _, ok := validation.Operations[key]; !ok {
+				t.Errorf("route %s has no validation operation", key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+
+	// Описания без маршрута устаревают незаметно
+	for key := range validation.Operations {
+		if !registered[key] {
+			t.Errorf("validation operation %s has no route", key)
+		}
+	}
+}
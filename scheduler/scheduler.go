@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"avito-project/models"
+)
+
+// DefaultInterval - период проверки расписания тендеров, если SCHEDULER_INTERVAL не задан
+const DefaultInterval = time.Minute
+
+// Run периодически публикует тендеры, время публикации которых наступило,
+// и закрывает тендеры с истекшим сроком подачи предложений. Работает до отмены ctx.
+func Run(ctx context.Context, tenders models.TenderRepository, interval time.Duration) {
+	log.Printf("Scheduler: Started with interval %v", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		tick(ctx, tenders, interval)
+
+		select {
+		case <-ctx.Done():
+			log.Println("Scheduler: Stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick выполняет одну проверку расписания; срок выполнения ограничен интервалом,
+// чтобы зависший запрос не задерживал следующие проверки
+func tick(ctx context.Context, tenders models.TenderRepository, interval time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, interval)
+	defer cancel()
+
+	published, err := tenders.PublishScheduled(ctx)
+	if err != nil {
+		log.Printf("Scheduler: Failed to publish scheduled tenders: %v", err)
+	}
+	for _, id := range published {
+		log.Printf("Scheduler: Tender %s published by schedule", id)
+	}
+
+	closed, err := tenders.CloseExpired(ctx)
+	if err != nil {
+		log.Printf("Scheduler: Failed to close expired tenders: %v", err)
+	}
+	for _, id := range closed {
+		log.Printf("Scheduler: Tender %s closed after submission deadline", id)
+	}
+}
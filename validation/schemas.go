@@ -0,0 +1,337 @@
+package validation
+
+// Схемы параметров, соответствующие components/schemas из задание/openapi.yml
+
+var (
+	username = Schema{Type: TypeString, MaxLength: 50}
+	password = Schema{Type: TypeString, MaxLength: 72}
+
+	tenderID          = Schema{Type: TypeString, Format: FormatUUID, MaxLength: 100}
+	tenderName        = Schema{Type: TypeString, MaxLength: 100}
+	tenderDescription = Schema{Type: TypeString, MaxLength: 500}
+	tenderServiceType = Schema{Type: TypeString, Enum: []string{"Construction", "Delivery", "Manufacture"}}
+	tenderStatus      = Schema{Type: TypeString, Enum: []string{"Created", "Published", "Closed"}, IgnoreCase: true}
+	organizationID    = Schema{Type: TypeString, Format: FormatUUID, MaxLength: 100}
+	statusReason      = Schema{Type: TypeString, MaxLength: 500}
+	tenderDateTime    = Schema{Type: TypeString, Format: FormatDateTime}
+	amount            = Schema{Type: TypeNumber, Minimum: intPtr(0), Scale: 2}
+	currency          = Schema{Type: TypeString, Enum: []string{"RUB", "USD", "EUR", "CNY"}}
+	tenderType        = Schema{Type: TypeString, Enum: []string{"standard", "auction"}}
+	auctionExtension  = Schema{Type: TypeInteger, Minimum: intPtr(1), Maximum: intPtr(60)}
+
+	organizationName        = Schema{Type: TypeString, MaxLength: 100}
+	organizationDescription = Schema{Type: TypeString, MaxLength: 500}
+	organizationType        = Schema{Type: TypeString, Enum: []string{"IE", "LLC", "JSC"}}
+	employeeID              = Schema{Type: TypeString, Format: FormatUUID, MaxLength: 100}
+	employeeFirstName       = Schema{Type: TypeString, MaxLength: 50}
+	employeeLastName        = Schema{Type: TypeString, MaxLength: 50}
+
+	bidID          = Schema{Type: TypeString, Format: FormatUUID, MaxLength: 100}
+	bidName        = Schema{Type: TypeString, MaxLength: 100}
+	bidDescription = Schema{Type: TypeString, MaxLength: 500}
+	bidStatus      = Schema{Type: TypeString, Enum: []string{"Created", "Published", "Canceled"}, IgnoreCase: true}
+	bidDecision    = Schema{Type: TypeString, Enum: []string{"Approved", "Rejected"}}
+	bidFeedback    = Schema{Type: TypeString, MaxLength: 1000}
+	bidAuthorType  = Schema{Type: TypeString, Enum: []string{"Organization", "User"}}
+	bidAuthorID    = Schema{Type: TypeString, Format: FormatUUID, MaxLength: 100}
+	bidSortBy      = Schema{Type: TypeString, Enum: []string{"name", "price", "price_desc"}}
+	deliveryTerms  = Schema{Type: TypeString, MaxLength: 500}
+
+	version = Schema{Type: TypeInteger, Minimum: intPtr(1)}
+
+	// Имя пользователя, от которого выполняется запрос. Параметр из спецификации
+	// принимается для совместимости, пользователь определяется по токену сессии.
+	callerUsername = Param{Name: "username", In: InQuery, Schema: username}
+
+	paginationLimit  = Param{Name: "limit", In: InQuery, Schema: Schema{Type: TypeInteger, Minimum: intPtr(0), Maximum: intPtr(50)}}
+	paginationOffset = Param{Name: "offset", In: InQuery, Schema: Schema{Type: TypeInteger, Minimum: intPtr(0)}}
+)
+
+// Operations описывает параметры каждого маршрута API.
+// Ключ - метод и шаблон пути маршрута gorilla/mux.
+var Operations = map[string]Operation{
+	"GET /api/ping": {},
+
+	"POST /api/auth/login": {
+		Body: map[string]Schema{
+			"username": username,
+			"password": password,
+		},
+		BodyRequired: []string{"username", "password"},
+	},
+	"POST /api/auth/logout": {},
+
+	"GET /api/tenders": {
+		Params: []Param{
+			paginationLimit,
+			paginationOffset,
+			{Name: "service_type", In: InQuery, Schema: Schema{Type: TypeArray, Items: &tenderServiceType}},
+		},
+	},
+	"POST /api/tenders/new": {
+		Body: map[string]Schema{
+			"name":                    tenderName,
+			"description":             tenderDescription,
+			"serviceType":             tenderServiceType,
+			"organizationId":          organizationID,
+			"submissionDeadline":      tenderDateTime,
+			"publishAt":               tenderDateTime,
+			"budget":                  amount,
+			"currency":                currency,
+			"type":                    tenderType,
+			"auctionStep":             amount,
+			"auctionExtensionMinutes": auctionExtension,
+			// Обязателен по спецификации, но пользователь определяется по токену
+			"creatorUsername": username,
+		},
+		BodyRequired: []string{"name", "description", "serviceType", "organizationId"},
+	},
+	"GET /api/tenders/my": {
+		Params: []Param{
+			paginationLimit,
+			paginationOffset,
+			{Name: "service_type", In: InQuery, Schema: Schema{Type: TypeArray, Items: &tenderServiceType}},
+			callerUsername,
+		},
+	},
+	"GET /api/tenders/{tenderId}/status": {
+		Params: []Param{
+			{Name: "tenderId", In: InPath, Required: true, Schema: tenderID},
+			callerUsername,
+		},
+	},
+	"PUT /api/tenders/{tenderId}/status": {
+		Params: []Param{
+			{Name: "tenderId", In: InPath, Required: true, Schema: tenderID},
+			{Name: "status", In: InQuery, Required: true, Schema: tenderStatus},
+			{Name: "reason", In: InQuery, Schema: statusReason},
+			callerUsername,
+		},
+	},
+	"GET /api/tenders/{tenderId}/auction": {
+		Params: []Param{
+			{Name: "tenderId", In: InPath, Required: true, Schema: tenderID},
+		},
+	},
+	"GET /api/tenders/{tenderId}/status_history": {
+		Params: []Param{
+			{Name: "tenderId", In: InPath, Required: true, Schema: tenderID},
+		},
+	},
+	"PATCH /api/tenders/{tenderId}/edit": {
+		Params: []Param{
+			{Name: "tenderId", In: InPath, Required: true, Schema: tenderID},
+			callerUsername,
+		},
+		Body: map[string]Schema{
+			"name":                    tenderName,
+			"description":             tenderDescription,
+			"serviceType":             tenderServiceType,
+			"submissionDeadline":      tenderDateTime,
+			"publishAt":               tenderDateTime,
+			"budget":                  amount,
+			"currency":                currency,
+			"auctionStep":             amount,
+			"auctionExtensionMinutes": auctionExtension,
+		},
+	},
+	"PUT /api/tenders/{tenderId}/rollback/{version}": {
+		Params: []Param{
+			{Name: "tenderId", In: InPath, Required: true, Schema: tenderID},
+			{Name: "version", In: InPath, Required: true, Schema: version},
+			callerUsername,
+		},
+	},
+	"GET /api/tenders/{tenderId}/versions": {
+		Params: []Param{
+			{Name: "tenderId", In: InPath, Required: true, Schema: tenderID},
+		},
+	},
+	"GET /api/tenders/{tenderId}/versions/{a}/diff/{b}": {
+		Params: []Param{
+			{Name: "tenderId", In: InPath, Required: true, Schema: tenderID},
+			{Name: "a", In: InPath, Required: true, Schema: version},
+			{Name: "b", In: InPath, Required: true, Schema: version},
+		},
+	},
+
+	"GET /api/employees": {
+		Params: []Param{
+			{Name: "username", In: InQuery, Required: true, Schema: username},
+		},
+	},
+	"POST /api/employees/new": {
+		Body: map[string]Schema{
+			"username":  username,
+			"firstName": employeeFirstName,
+			"lastName":  employeeLastName,
+			"password":  password,
+		},
+		BodyRequired: []string{"username", "password"},
+	},
+	"GET /api/employees/{employeeId}": {
+		Params: []Param{
+			{Name: "employeeId", In: InPath, Required: true, Schema: employeeID},
+		},
+	},
+	"PATCH /api/employees/{employeeId}/edit": {
+		Params: []Param{
+			{Name: "employeeId", In: InPath, Required: true, Schema: employeeID},
+		},
+		Body: map[string]Schema{
+			"firstName": employeeFirstName,
+			"lastName":  employeeLastName,
+		},
+	},
+	"PUT /api/employees/{employeeId}/deactivate": {
+		Params: []Param{
+			{Name: "employeeId", In: InPath, Required: true, Schema: employeeID},
+		},
+	},
+	"PUT /api/employees/{employeeId}/activate": {
+		Params: []Param{
+			{Name: "employeeId", In: InPath, Required: true, Schema: employeeID},
+		},
+	},
+
+	"GET /api/organizations": {
+		Params: []Param{
+			paginationLimit,
+			paginationOffset,
+		},
+	},
+	"POST /api/organizations/new": {
+		Body: map[string]Schema{
+			"name":        organizationName,
+			"description": organizationDescription,
+			"type":        organizationType,
+		},
+		BodyRequired: []string{"name", "type"},
+	},
+	"GET /api/organizations/{organizationId}": {
+		Params: []Param{
+			{Name: "organizationId", In: InPath, Required: true, Schema: organizationID},
+		},
+	},
+	"DELETE /api/organizations/{organizationId}": {
+		Params: []Param{
+			{Name: "organizationId", In: InPath, Required: true, Schema: organizationID},
+		},
+	},
+	"PATCH /api/organizations/{organizationId}/edit": {
+		Params: []Param{
+			{Name: "organizationId", In: InPath, Required: true, Schema: organizationID},
+		},
+		Body: map[string]Schema{
+			"name":        organizationName,
+			"description": organizationDescription,
+			"type":        organizationType,
+		},
+	},
+	"GET /api/organizations/{organizationId}/responsibles": {
+		Params: []Param{
+			{Name: "organizationId", In: InPath, Required: true, Schema: organizationID},
+		},
+	},
+	"POST /api/organizations/{organizationId}/responsibles": {
+		Params: []Param{
+			{Name: "organizationId", In: InPath, Required: true, Schema: organizationID},
+		},
+		Body: map[string]Schema{
+			"username": username,
+		},
+		BodyRequired: []string{"username"},
+	},
+	"DELETE /api/organizations/{organizationId}/responsibles/{userId}": {
+		Params: []Param{
+			{Name: "organizationId", In: InPath, Required: true, Schema: organizationID},
+			{Name: "userId", In: InPath, Required: true, Schema: employeeID},
+		},
+	},
+
+	"POST /api/bids/new": {
+		Body: map[string]Schema{
+			"name":          bidName,
+			"description":   bidDescription,
+			"tenderId":      tenderID,
+			"authorType":    bidAuthorType,
+			"authorId":      bidAuthorID,
+			"price":         amount,
+			"currency":      currency,
+			"deliveryTerms": deliveryTerms,
+		},
+		BodyRequired: []string{"name", "description", "tenderId", "authorType", "authorId", "price", "currency", "deliveryTerms"},
+	},
+	"GET /api/bids/my": {
+		Params: []Param{
+			paginationLimit,
+			paginationOffset,
+			callerUsername,
+		},
+	},
+	"GET /api/bids/{tenderId}/list": {
+		Params: []Param{
+			{Name: "tenderId", In: InPath, Required: true, Schema: tenderID},
+			paginationLimit,
+			paginationOffset,
+			{Name: "sort_by", In: InQuery, Schema: bidSortBy},
+			callerUsername,
+		},
+	},
+	"GET /api/bids/{bidId}/status": {
+		Params: []Param{
+			{Name: "bidId", In: InPath, Required: true, Schema: bidID},
+			callerUsername,
+		},
+	},
+	"PUT /api/bids/{bidId}/status": {
+		Params: []Param{
+			{Name: "bidId", In: InPath, Required: true, Schema: bidID},
+			{Name: "status", In: InQuery, Required: true, Schema: bidStatus},
+			callerUsername,
+		},
+	},
+	"PATCH /api/bids/{bidId}/edit": {
+		Params: []Param{
+			{Name: "bidId", In: InPath, Required: true, Schema: bidID},
+			callerUsername,
+		},
+		Body: map[string]Schema{
+			"name":          bidName,
+			"description":   bidDescription,
+			"price":         amount,
+			"currency":      currency,
+			"deliveryTerms": deliveryTerms,
+		},
+	},
+	"PUT /api/bids/{bidId}/submit_decision": {
+		Params: []Param{
+			{Name: "bidId", In: InPath, Required: true, Schema: bidID},
+			{Name: "decision", In: InQuery, Required: true, Schema: bidDecision},
+			callerUsername,
+		},
+	},
+	"PUT /api/bids/{bidId}/feedback": {
+		Params: []Param{
+			{Name: "bidId", In: InPath, Required: true, Schema: bidID},
+			{Name: "bidFeedback", In: InQuery, Required: true, Schema: bidFeedback},
+			callerUsername,
+		},
+	},
+	"PUT /api/bids/{bidId}/rollback/{version}": {
+		Params: []Param{
+			{Name: "bidId", In: InPath, Required: true, Schema: bidID},
+			{Name: "version", In: InPath, Required: true, Schema: version},
+			callerUsername,
+		},
+	},
+	"GET /api/bids/{tenderId}/reviews": {
+		Params: []Param{
+			{Name: "tenderId", In: InPath, Required: true, Schema: tenderID},
+			{Name: "authorUsername", In: InQuery, Required: true, Schema: username},
+			{Name: "requesterUsername", In: InQuery, Schema: username},
+			paginationLimit,
+			paginationOffset,
+		},
+	},
+}
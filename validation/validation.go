@@ -0,0 +1,325 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"avito-project/response"
+
+	"github.com/gorilla/mux"
+)
+
+// Типы значений схемы
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeArray   = "array"
+)
+
+// Форматы строковых значений
+const (
+	FormatUUID     = "uuid"      // идентификаторы, присвоенные сервером
+	FormatDateTime = "date-time" // дата и время в RFC 3339
+)
+
+// Расположение параметра запроса
+const (
+	InPath  = "path"
+	InQuery = "query"
+)
+
+// maxBodyBytes - предельный размер тела запроса. Тела API - небольшие JSON объекты,
+// больший запрос отклоняется до чтения в память.
+const maxBodyBytes = 1 << 20
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Schema описывает ограничения на значение параметра
+type Schema struct {
+	Type       string
+	Format     string
+	MaxLength  int
+	Enum       []string
+	IgnoreCase bool // статусы принимаются в любом регистре, обработчики приводят их к верхнему
+	Minimum    *int
+	Maximum    *int
+	Scale      int // для TypeNumber - допустимое число знаков после запятой, 0 - не ограничено
+	Items      *Schema
+}
+
+// Param описывает параметр пути или query
+type Param struct {
+	Name     string
+	In       string
+	Required bool
+	Schema   Schema
+}
+
+// Operation описывает параметры и тело запроса одного маршрута
+type Operation struct {
+	Params       []Param
+	Body         map[string]Schema
+	BodyRequired []string
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// Middleware проверяет параметры и тело запроса по описанию маршрута в Operations.
+// Если хотя бы один параметр некорректен, весь запрос отклоняется с кодом 400.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+		route := mux.CurrentRoute(r)
+		if route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		op, ok := Operations[r.Method+" "+template]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		errs := validateParams(r, op.Params)
+
+		if op.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				log.Printf("Validation: Request body exceeds %d bytes", tooLarge.Limit)
+				response.Error(w, http.StatusRequestEntityTooLarge, response.CodeInvalidRequest, "Request body is too large")
+				return
+			}
+			if err != nil {
+				log.Printf("Validation: Failed to read request body: %v", err)
+				response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid input")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			errs = append(errs, validateBody(body, op)...)
+		}
+
+		if len(errs) > 0 {
+			reason := strings.Join(errs, "; ")
+			log.Printf("Validation: %s %s rejected: %s", r.Method, template, reason)
+			response.Error(w, http.StatusBadRequest, response.CodeInvalidRequest, reason)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validateParams проверяет параметры пути и query
+func validateParams(r *http.Request, params []Param) []string {
+	var errs []string
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	for _, p := range params {
+		var values []string
+		if p.In == InPath {
+			if v, ok := vars[p.Name]; ok && v != "" {
+				values = []string{v}
+			}
+		} else {
+			for _, v := range query[p.Name] {
+				if v != "" {
+					values = append(values, v)
+				}
+			}
+		}
+
+		if len(values) == 0 {
+			if p.Required {
+				errs = append(errs, fmt.Sprintf("parameter %s is required", p.Name))
+			}
+			continue
+		}
+
+		schema := p.Schema
+		if schema.Type == TypeArray {
+			schema = *schema.Items
+		} else if len(values) > 1 {
+			errs = append(errs, fmt.Sprintf("parameter %s must be specified once", p.Name))
+			continue
+		}
+
+		for _, v := range values {
+			if err := checkRaw(v, schema); err != "" {
+				errs = append(errs, fmt.Sprintf("parameter %s %s", p.Name, err))
+			}
+		}
+	}
+	return errs
+}
+
+// validateBody проверяет поля JSON тела запроса
+func validateBody(body []byte, op Operation) []string {
+	// Числа разбираются как json.Number, чтобы точность проверялась без округления
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil || fields == nil {
+		return []string{"request body must be a JSON object"}
+	}
+
+	var errs []string
+	for _, name := range op.BodyRequired {
+		if _, ok := fields[name]; !ok {
+			errs = append(errs, fmt.Sprintf("field %s is required", name))
+		}
+	}
+
+	// Поля, не описанные в схеме, не принимаются: опечатка в имени поля
+	// иначе молча игнорировалась бы обработчиком
+	var unknown []string
+	for name := range fields {
+		if _, ok := op.Body[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, fmt.Sprintf("field %s is not allowed", name))
+	}
+
+	// Поля проверяются в фиксированном порядке, чтобы текст ошибки был стабильным
+	names := make([]string, 0, len(op.Body))
+	for name := range op.Body {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if err := checkJSON(value, op.Body[name]); err != "" {
+			errs = append(errs, fmt.Sprintf("field %s %s", name, err))
+		}
+	}
+	return errs
+}
+
+// checkRaw проверяет строковое значение параметра пути или query
+func checkRaw(value string, schema Schema) string {
+	if schema.Type == TypeInteger {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "must be an integer"
+		}
+		return checkInteger(n, schema)
+	}
+	return checkString(value, schema)
+}
+
+// checkJSON проверяет значение поля JSON тела запроса
+func checkJSON(value interface{}, schema Schema) string {
+	switch schema.Type {
+	case TypeInteger:
+		number, ok := value.(json.Number)
+		if !ok {
+			return "must be an integer"
+		}
+		n, err := strconv.Atoi(number.String())
+		if err != nil {
+			return "must be an integer"
+		}
+		return checkInteger(n, schema)
+	case TypeNumber:
+		number, ok := value.(json.Number)
+		if !ok {
+			return "must be a number"
+		}
+		return checkNumber(number, schema)
+	default:
+		s, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		return checkString(s, schema)
+	}
+}
+
+func checkInteger(n int, schema Schema) string {
+	if schema.Minimum != nil && n < *schema.Minimum {
+		return fmt.Sprintf("must be at least %d", *schema.Minimum)
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		return fmt.Sprintf("must be at most %d", *schema.Maximum)
+	}
+	return ""
+}
+
+func checkNumber(number json.Number, schema Schema) string {
+	if schema.Scale > 0 && !withinScale(number, schema.Scale) {
+		return fmt.Sprintf("must have at most %d decimal places", schema.Scale)
+	}
+	n, err := number.Float64()
+	if err != nil {
+		return "must be a number"
+	}
+	if schema.Minimum != nil && n < float64(*schema.Minimum) {
+		return fmt.Sprintf("must be at least %d", *schema.Minimum)
+	}
+	if schema.Maximum != nil && n > float64(*schema.Maximum) {
+		return fmt.Sprintf("must be at most %d", *schema.Maximum)
+	}
+	return ""
+}
+
+// withinScale проверяет, что в десятичной записи числа не больше scale знаков после запятой
+func withinScale(number json.Number, scale int) bool {
+	r, ok := new(big.Rat).SetString(number.String())
+	if !ok {
+		return false
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	return r.Mul(r, new(big.Rat).SetInt(pow)).IsInt()
+}
+
+func checkString(s string, schema Schema) string {
+	if schema.MaxLength > 0 && utf8.RuneCountInString(s) > schema.MaxLength {
+		return fmt.Sprintf("must be at most %d characters long", schema.MaxLength)
+	}
+	if schema.Format == FormatUUID && !uuidPattern.MatchString(s) {
+		return "must be a valid UUID"
+	}
+	if schema.Format == FormatDateTime {
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return "must be a date-time in RFC 3339 format"
+		}
+	}
+	if len(schema.Enum) > 0 {
+		for _, allowed := range schema.Enum {
+			if s == allowed || (schema.IgnoreCase && strings.EqualFold(s, allowed)) {
+				return ""
+			}
+		}
+		return "must be one of: " + strings.Join(schema.Enum, ", ")
+	}
+	return ""
+}
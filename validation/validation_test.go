@@ -0,0 +1,194 @@
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"avito-project/response"
+)
+
+// newTestRouter регистрирует маршруты с проверкой параметров и обработчиком,
+// отвечающим 200, если запрос прошел проверку
+func newTestRouter(routes ...string) *mux.Router {
+	router := mux.NewRouter()
+	router.Use(Middleware)
+	for _, route := range routes {
+		method, template, _ := strings.Cut(route, " ")
+		router.HandleFunc(template, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}).Methods(method)
+	}
+	return router
+}
+
+func TestMiddleware(t *testing.T) {
+	router := newTestRouter(
+		"POST /api/tenders/new",
+		"PATCH /api/bids/{bidId}/edit",
+		"GET /api/bids/{tenderId}/list",
+		"POST /api/auth/login",
+	)
+
+	const organizationID = "550e8400-e29b-41d4-a716-446655440000"
+	const bidID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		reason string // пустая строка - запрос проходит проверку
+	}{
+		{
+			name:   "valid tender",
+			method: http.MethodPost,
+			target: "/api/tenders/new",
+			body:   `{"name":"Tender","description":"Build","serviceType":"Construction","organizationId":"` + organizationID + `","budget":1000,"currency":"RUB"}`,
+		},
+		{
+			// Тело из задание/openapi.yml: обязательные поля createTender с примерами схем
+			name:   "spec createTender example",
+			method: http.MethodPost,
+			target: "/api/tenders/new",
+			body:   `{"name":"Доставка товаров Алексея","description":"Доставить товары из Казани в Москву","serviceType":"Delivery","organizationId":"` + organizationID + `","creatorUsername":"test_user"}`,
+		},
+		{
+			name:   "tender with unknown field",
+			method: http.MethodPost,
+			target: "/api/tenders/new",
+			body:   `{"name":"Tender","description":"Build","serviceType":"Construction","organizationId":"` + organizationID + `","budjet":1000}`,
+			reason: "field budjet is not allowed",
+		},
+		{
+			name:   "tender missing required field",
+			method: http.MethodPost,
+			target: "/api/tenders/new",
+			body:   `{"name":"Tender","description":"Build","organizationId":"` + organizationID + `"}`,
+			reason: "field serviceType is required",
+		},
+		{
+			name:   "tender with invalid enum and uuid",
+			method: http.MethodPost,
+			target: "/api/tenders/new",
+			body:   `{"name":"Tender","description":"Build","serviceType":"Cleaning","organizationId":"org-1"}`,
+			reason: "field organizationId must be a valid UUID; field serviceType must be one of: Construction, Delivery, Manufacture",
+		},
+		{
+			name:   "bid price of wrong type",
+			method: http.MethodPatch,
+			target: "/api/bids/" + bidID + "/edit",
+			body:   `{"price":"cheap"}`,
+			reason: "field price must be a number",
+		},
+		{
+			name:   "bid price below a kopeck",
+			method: http.MethodPatch,
+			target: "/api/bids/" + bidID + "/edit",
+			body:   `{"price":10.005}`,
+			reason: "field price must have at most 2 decimal places",
+		},
+		{
+			name:   "bid edit with invalid id",
+			method: http.MethodPatch,
+			target: "/api/bids/42/edit",
+			body:   `{"name":"Bid"}`,
+			reason: "parameter bidId must be a valid UUID",
+		},
+		{
+			name:   "bid edit with non-object body",
+			method: http.MethodPatch,
+			target: "/api/bids/" + bidID + "/edit",
+			body:   `["name"]`,
+			reason: "request body must be a JSON object",
+		},
+		{
+			name:   "bid list with spec username",
+			method: http.MethodGet,
+			target: "/api/bids/" + organizationID + "/list?username=test_user",
+		},
+		{
+			name:   "bid list with limit of wrong type",
+			method: http.MethodGet,
+			target: "/api/bids/" + organizationID + "/list?limit=ten",
+			reason: "parameter limit must be an integer",
+		},
+		{
+			name:   "bid list with limit out of range",
+			method: http.MethodGet,
+			target: "/api/bids/" + organizationID + "/list?limit=51&sort_by=price",
+			reason: "parameter limit must be at most 50",
+		},
+		{
+			name:   "login missing password",
+			method: http.MethodPost,
+			target: "/api/auth/login",
+			body:   `{"username":"user"}`,
+			reason: "field password is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if tt.reason == "" {
+				if rec.Code != http.StatusOK {
+					t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+				}
+				return
+			}
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+			var body response.ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if body.Code != response.CodeInvalidRequest {
+				t.Errorf("code = %s, want %s", body.Code, response.CodeInvalidRequest)
+			}
+			if body.Reason != tt.reason {
+				t.Errorf("reason = %q, want %q", body.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+// TestMiddlewarePassesBody проверяет, что обработчик получает тело запроса после проверки
+func TestMiddlewarePassesBody(t *testing.T) {
+	const body = `{"username":"user","password":"secret"}`
+
+	router := mux.NewRouter()
+	router.Use(Middleware)
+	router.HandleFunc("/api/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		var input map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input["password"] != "secret" {
+			t.Errorf("handler received body %v (err=%v)", input, err)
+		}
+	}).Methods(http.MethodPost)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestMiddlewareBodyTooLarge(t *testing.T) {
+	router := newTestRouter("POST /api/auth/login")
+	body := `{"username":"user","password":"` + strings.Repeat("x", maxBodyBytes) + `"}`
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(body)))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}